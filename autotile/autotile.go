@@ -0,0 +1,197 @@
+// Package autotile turns a boolean or IntGrid map into tile indices using
+// rule sets (4-bit edges, 8-bit/47-tile blob, or custom pattern rules with
+// weighted random variants), and re-tiles only the affected neighbourhood
+// when a single cell changes at runtime.
+package autotile
+
+import "github.com/henrypekny/runt/graphics"
+
+// Map is a grid of IntGrid values; 0 means empty.  Boolean maps use 1 for
+// filled cells.
+type Map struct {
+	columns, rows int
+	cells         []int
+
+	// Edge is the value assumed outside the map.  Setting it to the wall
+	// value makes walls continue seamlessly past the border.
+	Edge int
+}
+
+// NewMap creates an empty columns×rows map.
+func NewMap(columns, rows int) *Map {
+	return &Map{columns: columns, rows: rows, cells: make([]int, columns*rows)}
+}
+
+// NewMapFromInts wraps row-major IntGrid values (e.g. an LDtk IntGrid layer).
+func NewMapFromInts(columns, rows int, values []int) *Map {
+	m := NewMap(columns, rows)
+	copy(m.cells, values)
+	return m
+}
+
+// NewMapFromBools converts row-major booleans to 1 (filled) / 0 (empty).
+func NewMapFromBools(columns, rows int, filled []bool) *Map {
+	m := NewMap(columns, rows)
+	for i, f := range filled {
+		if f && i < len(m.cells) {
+			m.cells[i] = 1
+		}
+	}
+	return m
+}
+
+// Columns and Rows report the map size in cells.
+func (m *Map) Columns() int { return m.columns }
+func (m *Map) Rows() int    { return m.rows }
+
+// Get returns the value at (col,row), or Edge outside the map.
+func (m *Map) Get(col, row int) int {
+	if col < 0 || row < 0 || col >= m.columns || row >= m.rows {
+		return m.Edge
+	}
+	return m.cells[row*m.columns+col]
+}
+
+// Set stores v at (col,row).  Use Tiler.Set to also re-tile.
+func (m *Map) Set(col, row, v int) {
+	if col < 0 || row < 0 || col >= m.columns || row >= m.rows {
+		return
+	}
+	m.cells[row*m.columns+col] = v
+}
+
+// Roll returns a stable random number in [0,1) for the cell being tiled.
+// Different salts give independent numbers for the same cell.
+type Roll func(salt uint32) float64
+
+// RuleSet decides the tile of one cell.
+type RuleSet interface {
+	// Tile returns the tile index for (col,row), or -1 when the rule set
+	// does not apply so the next one is tried.
+	Tile(m *Map, col, row int, roll Roll) int
+	// Radius is how many cells away a change can still affect the result
+	// (1 for rules looking at the 3×3 neighbourhood).
+	Radius() int
+}
+
+// Cell is one re-tiled cell.
+type Cell struct {
+	Col, Row int
+	Tile     int
+}
+
+// Tiler applies rule sets to a Map and keeps the resulting indices.  Rule
+// sets are tried in order; the first that returns a tile wins, and cells
+// no rule matches stay graphics.TileEmpty.
+type Tiler struct {
+	Map   *Map
+	Rules []RuleSet
+
+	// Seed varies the random variants while keeping them stable per cell.
+	Seed uint64
+
+	// Layer, when set, receives every tile as it is computed; call TileAll
+	// after attaching it to fill it in.
+	Layer *graphics.TileLayer
+
+	tiles []int
+}
+
+// NewTiler creates a Tiler and tiles the whole map.
+func NewTiler(m *Map, rules ...RuleSet) *Tiler {
+	t := &Tiler{Map: m, Rules: rules}
+	t.TileAll()
+	return t
+}
+
+// TileAll recomputes every cell and returns the row-major tile indices.
+func (t *Tiler) TileAll() []int {
+	m := t.Map
+	if len(t.tiles) != m.columns*m.rows {
+		t.tiles = make([]int, m.columns*m.rows)
+	}
+	for row := 0; row < m.rows; row++ {
+		for col := 0; col < m.columns; col++ {
+			tile := t.compute(col, row)
+			t.tiles[row*m.columns+col] = tile
+			if t.Layer != nil {
+				t.Layer.SetTile(col, row, tile)
+			}
+		}
+	}
+	return t.tiles
+}
+
+// Tiles returns the row-major tile indices from the last tiling.
+func (t *Tiler) Tiles() []int { return t.tiles }
+
+// Tile returns the computed tile at (col,row).
+func (t *Tiler) Tile(col, row int) int {
+	if col < 0 || row < 0 || col >= t.Map.columns || row >= t.Map.rows {
+		return graphics.TileEmpty
+	}
+	return t.tiles[row*t.Map.columns+col]
+}
+
+// Set changes one map cell and re-tiles only the cells within the largest
+// rule radius around it.  It returns the cells whose tile changed.
+func (t *Tiler) Set(col, row, v int) []Cell {
+	if t.Map.Get(col, row) == v {
+		return nil
+	}
+	t.Map.Set(col, row, v)
+	return t.Retile(col-t.radius(), row-t.radius(), 2*t.radius()+1, 2*t.radius()+1)
+}
+
+// Retile recomputes the w×h block of cells at (col,row) and returns the
+// cells whose tile changed.  Use it after editing several cells through
+// Map.Set directly (grow the block by the rule radius).
+func (t *Tiler) Retile(col, row, w, h int) []Cell {
+	m := t.Map
+	var changed []Cell
+	for r := max(row, 0); r < min(row+h, m.rows); r++ {
+		for c := max(col, 0); c < min(col+w, m.columns); c++ {
+			tile := t.compute(c, r)
+			i := r*m.columns + c
+			if t.tiles[i] == tile {
+				continue
+			}
+			t.tiles[i] = tile
+			changed = append(changed, Cell{c, r, tile})
+			if t.Layer != nil {
+				t.Layer.SetTile(c, r, tile)
+			}
+		}
+	}
+	return changed
+}
+
+func (t *Tiler) radius() int {
+	r := 0
+	for _, rs := range t.Rules {
+		r = max(r, rs.Radius())
+	}
+	return r
+}
+
+func (t *Tiler) compute(col, row int) int {
+	roll := func(salt uint32) float64 {
+		return cellRandom(t.Seed, col, row, salt)
+	}
+	for _, rs := range t.Rules {
+		if tile := rs.Tile(t.Map, col, row, roll); tile >= 0 {
+			return tile
+		}
+	}
+	return graphics.TileEmpty
+}
+
+// cellRandom hashes (seed, col, row, salt) with splitmix64 into [0,1).
+func cellRandom(seed uint64, col, row int, salt uint32) float64 {
+	z := seed ^ uint64(uint32(col))<<32 ^ uint64(uint32(row)) ^ uint64(salt)*0x9E3779B97F4A7C15
+	z += 0x9E3779B97F4A7C15
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	z ^= z >> 31
+	return float64(z>>11) / (1 << 53)
+}
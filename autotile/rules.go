@@ -0,0 +1,281 @@
+package autotile
+
+import "sort"
+
+// matches reports whether v belongs to the terrain `value` (0 = any
+// non-empty value).
+func matches(v, value int) bool {
+	if value == 0 {
+		return v != 0
+	}
+	return v == value
+}
+
+// -----------------------------------------------------------------------------
+// 4-bit edge rules
+// -----------------------------------------------------------------------------
+
+// Edge bits for Bitmask4.
+const (
+	North = 1 << iota
+	East
+	South
+	West
+)
+
+// Bitmask4 picks one of 16 tiles from the four edge neighbours of each
+// cell of the given terrain value.  Tiles is indexed by the sum of the
+// North/East/South/West bits of the matching neighbours.
+type Bitmask4 struct {
+	Value int
+	Tiles [16]int
+}
+
+func (b *Bitmask4) Radius() int { return 1 }
+
+func (b *Bitmask4) Tile(m *Map, col, row int, _ Roll) int {
+	if !matches(m.Get(col, row), b.Value) {
+		return -1
+	}
+	mask := 0
+	if matches(m.Get(col, row-1), b.Value) {
+		mask |= North
+	}
+	if matches(m.Get(col+1, row), b.Value) {
+		mask |= East
+	}
+	if matches(m.Get(col, row+1), b.Value) {
+		mask |= South
+	}
+	if matches(m.Get(col-1, row), b.Value) {
+		mask |= West
+	}
+	return b.Tiles[mask]
+}
+
+// -----------------------------------------------------------------------------
+// 8-bit blob rules
+// -----------------------------------------------------------------------------
+
+// Neighbour bits for Blob, clockwise from north.
+const (
+	BlobN = 1 << iota
+	BlobNE
+	BlobE
+	BlobSE
+	BlobS
+	BlobSW
+	BlobW
+	BlobNW
+)
+
+// Blob picks a tile from all eight neighbours.  Corners only count when
+// both adjacent edges are set, which leaves the 47 distinct masks of the
+// classic blob tileset.
+type Blob struct {
+	Value  int
+	lookup [256]int
+}
+
+// NewBlob maps the 47 blob masks, in the ascending order returned by
+// BlobMasks, onto tiles[0..46].
+func NewBlob(value int, tiles []int) *Blob {
+	m := make(map[uint8]int, len(tiles))
+	for i, mask := range BlobMasks() {
+		if i < len(tiles) {
+			m[mask] = tiles[i]
+		}
+	}
+	return NewBlobMap(value, m)
+}
+
+// NewBlobMap maps reduced 8-bit masks to tiles explicitly.  Masks missing
+// from the map produce no tile.
+func NewBlobMap(value int, tiles map[uint8]int) *Blob {
+	b := &Blob{Value: value}
+	for i := range b.lookup {
+		b.lookup[i] = -1
+	}
+	for mask, tile := range tiles {
+		b.lookup[mask] = tile
+	}
+	return b
+}
+
+func (b *Blob) Radius() int { return 1 }
+
+func (b *Blob) Tile(m *Map, col, row int, _ Roll) int {
+	if !matches(m.Get(col, row), b.Value) {
+		return -1
+	}
+	on := func(dc, dr int) bool { return matches(m.Get(col+dc, row+dr), b.Value) }
+	var mask uint8
+	if on(0, -1) {
+		mask |= BlobN
+	}
+	if on(1, 0) {
+		mask |= BlobE
+	}
+	if on(0, 1) {
+		mask |= BlobS
+	}
+	if on(-1, 0) {
+		mask |= BlobW
+	}
+	if mask&(BlobN|BlobE) == BlobN|BlobE && on(1, -1) {
+		mask |= BlobNE
+	}
+	if mask&(BlobS|BlobE) == BlobS|BlobE && on(1, 1) {
+		mask |= BlobSE
+	}
+	if mask&(BlobS|BlobW) == BlobS|BlobW && on(-1, 1) {
+		mask |= BlobSW
+	}
+	if mask&(BlobN|BlobW) == BlobN|BlobW && on(-1, -1) {
+		mask |= BlobNW
+	}
+	return b.lookup[mask]
+}
+
+// BlobMasks returns the 47 reduced blob masks in ascending order.
+func BlobMasks() []uint8 {
+	seen := make(map[uint8]bool)
+	for raw := 0; raw < 256; raw++ {
+		mask := uint8(raw)
+		if mask&(BlobN|BlobE) != BlobN|BlobE {
+			mask &^= BlobNE
+		}
+		if mask&(BlobS|BlobE) != BlobS|BlobE {
+			mask &^= BlobSE
+		}
+		if mask&(BlobS|BlobW) != BlobS|BlobW {
+			mask &^= BlobSW
+		}
+		if mask&(BlobN|BlobW) != BlobN|BlobW {
+			mask &^= BlobNW
+		}
+		seen[mask] = true
+	}
+	out := make([]uint8, 0, len(seen))
+	for mask := range seen {
+		out = append(out, mask)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// Pattern rules
+// -----------------------------------------------------------------------------
+
+// Pattern cell conditions.  A positive value v requires the cell to equal
+// v, a negative value -v requires it to differ from v.
+const (
+	Any    = 0        // don't care
+	Filled = 1000001  // any non-zero value
+	Empty  = -1000001 // zero
+)
+
+// Rule is a custom pattern rule in the style of LDtk auto-layers.
+type Rule struct {
+	// Size is the odd pattern width (3 for a 3×3 neighbourhood); 0 takes
+	// it from len(Pattern).
+	Size int
+	// Pattern holds Size×Size conditions, row-major, centre included.
+	Pattern []int
+	// Tiles are the candidate results; one is picked per cell using
+	// Weights (nil = equal weights).
+	Tiles   []int
+	Weights []float64
+	// Chance is the probability that a matching cell uses this rule
+	// (0 means always).
+	Chance float64
+}
+
+// Rules applies pattern rules in order; the first matching rule wins.
+type Rules []*Rule
+
+func (rs Rules) Radius() int {
+	r := 0
+	for _, rule := range rs {
+		r = max(r, rule.size()/2)
+	}
+	return r
+}
+
+func (rs Rules) Tile(m *Map, col, row int, roll Roll) int {
+	for i, rule := range rs {
+		if len(rule.Tiles) == 0 || !rule.match(m, col, row) {
+			continue
+		}
+		salt := uint32(i) * 2
+		if rule.Chance > 0 && rule.Chance < 1 && roll(salt) >= rule.Chance {
+			continue
+		}
+		return rule.pick(roll(salt + 1))
+	}
+	return -1
+}
+
+// size is Size, or the integer square root of len(Pattern) when unset.
+func (r *Rule) size() int {
+	if r.Size > 0 {
+		return r.Size
+	}
+	n := 0
+	for (n+1)*(n+1) <= len(r.Pattern) {
+		n++
+	}
+	return n
+}
+
+func (r *Rule) match(m *Map, col, row int) bool {
+	size := r.size()
+	if size == 0 {
+		return true // no conditions
+	}
+	half := size / 2
+	for i, cond := range r.Pattern {
+		if cond == Any {
+			continue
+		}
+		v := m.Get(col+i%size-half, row+i/size-half)
+		switch {
+		case cond == Filled:
+			if v == 0 {
+				return false
+			}
+		case cond == Empty:
+			if v != 0 {
+				return false
+			}
+		case cond > 0:
+			if v != cond {
+				return false
+			}
+		default:
+			if v == -cond {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *Rule) pick(x float64) int {
+	if len(r.Weights) != len(r.Tiles) {
+		return r.Tiles[int(x*float64(len(r.Tiles)))%len(r.Tiles)]
+	}
+	total := 0.0
+	for _, w := range r.Weights {
+		total += w
+	}
+	x *= total
+	for i, w := range r.Weights {
+		if x < w {
+			return r.Tiles[i]
+		}
+		x -= w
+	}
+	return r.Tiles[len(r.Tiles)-1]
+}
@@ -0,0 +1,282 @@
+// runt/camera.go
+package runt
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// -----------------------------------------------------------------------------
+// Camera
+// -----------------------------------------------------------------------------
+
+// Positioned is anything a Camera can follow; every BaseEntity is one.
+type Positioned interface {
+	X() float32
+	Y() float32
+}
+
+// Camera is a World's view.  X,Y is the top-left of the view in world
+// units; graphics subtract it (times their ScrollX/Y, for parallax) when
+// they draw.  Zoom, Rotation and shake are applied on top by the Engine
+// around the centre of the screen.
+type Camera struct {
+	X, Y     float32
+	Zoom     float32 // 1 = no zoom
+	Rotation float32 // degrees
+
+	// Smoothing is how quickly the camera catches up with its target, as a
+	// rate per second (around 5–10 feels right); 0 snaps.
+	Smoothing float32
+
+	// LookAhead leads the target by its velocity times this many seconds.
+	LookAhead float32
+
+	// PixelSnap rounds the view to whole pixels so pixel art doesn't
+	// shimmer.
+	PixelSnap bool
+
+	// Shake: offset and angle are MaxShake/MaxShakeAngle × trauma², and
+	// trauma drains at TraumaDecay per second.
+	Trauma        float32
+	TraumaDecay   float32
+	MaxShake      float32 // pixels
+	MaxShakeAngle float32 // degrees
+	ShakeSpeed    float32 // noise frequency
+
+	width, height  float32 // view size; 0 = the screen
+	target         Positioned
+	deadW, deadH   float32
+	bounds         rl.Rectangle
+	bounded        bool
+	lastTX, lastTY float32
+	hasLast        bool
+	leadX, leadY   float32
+	prevX, prevY   float32 // at the previous fixed tick
+	shakeX, shakeY float32
+	shakeA, shakeT float32
+}
+
+// NewCamera returns a camera at the origin with sensible shake defaults.
+func NewCamera() *Camera {
+	return &Camera{
+		Zoom:          1,
+		TraumaDecay:   1.5,
+		MaxShake:      8,
+		MaxShakeAngle: 3,
+		ShakeSpeed:    30,
+	}
+}
+
+// Follow keeps target centred (subject to dead zone, look-ahead, smoothing
+// and bounds) on every Update.  nil stops following.
+func (c *Camera) Follow(target Positioned) {
+	c.target = target
+	c.hasLast = false
+	c.leadX, c.leadY = 0, 0
+}
+
+// Target returns what the camera follows, or nil.
+func (c *Camera) Target() Positioned { return c.target }
+
+// SetDeadZone lets the target move inside a w×h box around the centre of
+// the view before the camera moves.
+func (c *Camera) SetDeadZone(w, h float32) { c.deadW, c.deadH = w, h }
+
+// SetBounds keeps the visible area inside the given world rectangle,
+// usually the level.
+func (c *Camera) SetBounds(x, y, w, h float32) {
+	c.bounds = rl.NewRectangle(x, y, w, h)
+	c.bounded = true
+}
+
+// ClearBounds lets the camera move anywhere.
+func (c *Camera) ClearBounds() { c.bounded = false }
+
+// SetSize sets the size of the area the camera shows, for cameras drawn
+// into a View smaller than the screen.  0×0 means Width×Height.
+func (c *Camera) SetSize(w, h float32) { c.width, c.height = w, h }
+
+// Size returns the size of the area the camera shows (before zoom).
+func (c *Camera) Size() (float32, float32) {
+	if c.width > 0 && c.height > 0 {
+		return c.width, c.height
+	}
+	return float32(Width), float32(Height)
+}
+
+// half returns half the view size.
+func (c *Camera) half() (float32, float32) {
+	w, h := c.Size()
+	return w / 2, h / 2
+}
+
+// Center returns the world point at the centre of the view.
+func (c *Camera) Center() (float32, float32) {
+	hw, hh := c.half()
+	return c.X + hw, c.Y + hh
+}
+
+// LookAt centres the view on (x,y) immediately, without smoothing or
+// interpolation.
+func (c *Camera) LookAt(x, y float32) {
+	hw, hh := c.half()
+	c.X, c.Y = x-hw, y-hh
+	c.clamp()
+	c.prevX, c.prevY = c.X, c.Y
+}
+
+// AddTrauma adds to the shake (clamped to 1).  0.3 is a bump, 1 an
+// explosion.
+func (c *Camera) AddTrauma(t float32) {
+	c.Trauma = min(max(c.Trauma+t, 0), 1)
+}
+
+// Update moves the camera towards its target and advances the shake.
+func (c *Camera) Update(dt float64) {
+	d := float32(dt)
+	if c.target != nil && d > 0 {
+		c.follow(d)
+	}
+	c.clamp()
+
+	// Shake runs on real time so it keeps going through hitstop.
+	rdt := float32(RealElapsed)
+	if rdt <= 0 {
+		rdt = d
+	}
+	c.shakeT += rdt
+	if c.Trauma > 0 {
+		c.Trauma = max(c.Trauma-c.TraumaDecay*rdt, 0)
+	}
+	k := c.Trauma * c.Trauma
+	t := c.shakeT * c.ShakeSpeed
+	c.shakeX = c.MaxShake * k * noise(t, 0)
+	c.shakeY = c.MaxShake * k * noise(t, 17.3)
+	c.shakeA = c.MaxShakeAngle * k * noise(t, 41.9)
+}
+
+// follow steps towards the target.
+func (c *Camera) follow(d float32) {
+	tx, ty := c.target.X(), c.target.Y()
+
+	if c.LookAhead != 0 && c.hasLast {
+		vx, vy := (tx-c.lastTX)/d, (ty-c.lastTY)/d
+		a := 1 - float32(math.Exp(-4*float64(d)))
+		c.leadX += (vx*c.LookAhead - c.leadX) * a
+		c.leadY += (vy*c.LookAhead - c.leadY) * a
+	}
+	c.lastTX, c.lastTY, c.hasLast = tx, ty, true
+	tx += c.leadX
+	ty += c.leadY
+
+	// Only chase the part of the target outside the dead zone.
+	cx, cy := c.Center()
+	dx := deadZone(tx-cx, c.deadW/2)
+	dy := deadZone(ty-cy, c.deadH/2)
+
+	if c.Smoothing > 0 {
+		a := 1 - float32(math.Exp(-float64(c.Smoothing*d)))
+		dx *= a
+		dy *= a
+	}
+	c.X += dx
+	c.Y += dy
+}
+
+// deadZone shrinks an offset by the dead zone's half size.
+func deadZone(off, half float32) float32 {
+	switch {
+	case off > half:
+		return off - half
+	case off < -half:
+		return off + half
+	}
+	return 0
+}
+
+// clamp keeps the visible area (after zoom) inside the bounds.
+func (c *Camera) clamp() {
+	if !c.bounded {
+		return
+	}
+	zoom := max(c.Zoom, 0.0001)
+	hw, hh := c.half()
+	zw, zh := hw/zoom, hh/zoom
+	cx, cy := c.Center()
+	cx = clampSpan(cx, c.bounds.X+zw, c.bounds.X+c.bounds.Width-zw)
+	cy = clampSpan(cy, c.bounds.Y+zh, c.bounds.Y+c.bounds.Height-zh)
+	c.X, c.Y = cx-hw, cy-hh
+}
+
+// clampSpan clamps v to [lo,hi], centring it when the span is too small.
+func clampSpan(v, lo, hi float32) float32 {
+	if lo > hi {
+		return (lo + hi) / 2
+	}
+	return min(max(v, lo), hi)
+}
+
+// noise is smooth pseudo-random motion in [-1,1].
+func noise(t, seed float32) float32 {
+	x := float64(t + seed)
+	return float32(0.6*math.Sin(x) + 0.4*math.Sin(x*2.71+1.3))
+}
+
+// Snapshot records the position before a fixed tick.
+func (c *Camera) Snapshot() { c.prevX, c.prevY = c.X, c.Y }
+
+// View returns the offset graphics should draw with this frame: blended
+// between ticks in fixed mode and rounded with PixelSnap.
+func (c *Camera) View() (float32, float32) {
+	x, y := c.X, c.Y
+	if Fixed {
+		x = c.prevX + (c.X-c.prevX)*Interp
+		y = c.prevY + (c.Y-c.prevY)*Interp
+	}
+	if c.PixelSnap {
+		x, y = float32(math.Round(float64(x))), float32(math.Round(float64(y)))
+	}
+	return x, y
+}
+
+// Camera2D is the zoom/rotation/shake transform around the screen centre
+// that the Engine draws the world with.  Translation is left to View.
+func (c *Camera) Camera2D() rl.Camera2D {
+	return c.camera2D(rl.NewRectangle(0, 0, float32(Width), float32(Height)))
+}
+
+// camera2D is Camera2D for a camera drawn into the screen rectangle r.
+func (c *Camera) camera2D(r rl.Rectangle) rl.Camera2D {
+	hw, hh := c.half()
+	sx, sy := c.shakeX, c.shakeY
+	if c.PixelSnap {
+		sx, sy = float32(math.Round(float64(sx))), float32(math.Round(float64(sy)))
+	}
+	zoom := c.Zoom
+	if zoom <= 0 {
+		zoom = 1
+	}
+	return rl.NewCamera2D(
+		rl.Vector2{X: r.X + r.Width/2, Y: r.Y + r.Height/2},
+		rl.Vector2{X: hw + sx, Y: hh + sy},
+		c.Rotation+c.shakeA,
+		zoom,
+	)
+}
+
+// ScreenToWorld converts virtual screen coordinates (e.g. the mouse) to
+// world coordinates, undoing zoom, rotation and the view offset.
+func (c *Camera) ScreenToWorld(x, y float32) (float32, float32) {
+	p := rl.GetScreenToWorld2D(rl.Vector2{X: x, Y: y}, c.Camera2D())
+	vx, vy := c.View()
+	return p.X + vx, p.Y + vy
+}
+
+// WorldToScreen converts world coordinates to virtual screen coordinates.
+func (c *Camera) WorldToScreen(x, y float32) (float32, float32) {
+	vx, vy := c.View()
+	p := rl.GetWorldToScreen2D(rl.Vector2{X: x - vx, Y: y - vy}, c.Camera2D())
+	return p.X, p.Y
+}
@@ -0,0 +1,241 @@
+// runt/config.go
+package runt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/henrypekny/runt/graphics"
+	"github.com/henrypekny/runt/loader"
+)
+
+// -----------------------------------------------------------------------------
+// Engine options
+// -----------------------------------------------------------------------------
+
+// WithSize sets the window size.  Without a virtual resolution it is also
+// the game's Width×Height.
+func WithSize(w, h int) Option {
+	return func(e *Engine) { e.winW, e.winH = w, h }
+}
+
+// WithTitle sets the window title.
+func WithTitle(title string) Option {
+	return func(e *Engine) { e.title = title }
+}
+
+// FPSMonitor, passed to WithFPS, renders at the monitor's refresh rate.
+const FPSMonitor = -1
+
+// WithFPS sets the target render frame rate; 0 renders as fast as possible
+// (or at the display rate with vsync) and FPSMonitor matches the monitor.
+// In fixed mode the render rate is independent of the tick rate, e.g. 120Hz
+// physics drawn at 60 FPS:
+//
+//	runt.NewEngine(game, runt.WithTickRate(120), runt.WithFPS(60))
+func WithFPS(fps int) Option {
+	return func(e *Engine) { e.fps = fps }
+}
+
+// WithTickRate switches to a fixed timestep of hz updates per second,
+// independent of the render rate.
+func WithTickRate(hz int) Option {
+	return func(e *Engine) {
+		if hz > 0 {
+			e.tickRate = time.Second / time.Duration(hz)
+			e.fixed = true
+		}
+	}
+}
+
+// WithFixedStep turns the fixed timestep on or off, keeping the tick rate.
+func WithFixedStep(on bool) Option {
+	return func(e *Engine) { e.fixed = on }
+}
+
+// WithMaxFrameSkip caps the fixed updates run in one frame.
+func WithMaxFrameSkip(n int) Option {
+	return func(e *Engine) { e.maxFrameSkip = max(n, 1) }
+}
+
+// WithMaxElapsed clamps a frame's dt, so a stall doesn't turn into a huge
+// simulation step.
+func WithMaxElapsed(d time.Duration) Option {
+	return func(e *Engine) { e.maxElapsed = d.Seconds() }
+}
+
+// WithBackground sets the clear color.
+func WithBackground(c Color) Option {
+	return func(e *Engine) { e.bg = c }
+}
+
+// WithAudio opens (default) or skips the audio device.
+func WithAudio(on bool) Option {
+	return func(e *Engine) { e.audio = on }
+}
+
+// WithWorld makes w the CurrentWorld when Run starts.
+func WithWorld(w *World) Option {
+	return func(e *Engine) { e.world = w }
+}
+
+// WithBatching draws sprites through a graphics.Batch, which groups each
+// entity's sprites by layer, texture, shader and blend mode to cut state
+// changes; see Engine.Batch.
+func WithBatching() Option {
+	return func(e *Engine) { e.batch = graphics.NewBatch() }
+}
+
+// -----------------------------------------------------------------------------
+// Config file
+// -----------------------------------------------------------------------------
+
+// Config mirrors the Engine options as a JSON file.  Zero or missing
+// fields leave the defaults alone.
+//
+//	{
+//	  "title": "My Game", "width": 1280, "height": 720,
+//	  "virtual_width": 320, "virtual_height": 180, "scale": "integer",
+//	  "fps": 0, "tick_rate": 60, "max_frame_skip": 5, "max_elapsed_ms": 100,
+//	  "background": "Charcoal", "vsync": true, "audio": true
+//	}
+type Config struct {
+	Title  string `json:"title"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+
+	VirtualWidth  int    `json:"virtual_width"`
+	VirtualHeight int    `json:"virtual_height"`
+	Scale         string `json:"scale"` // "none", "integer" or "fit"
+
+	FPS          *int `json:"fps"` // 0 = uncapped, -1 = monitor refresh
+	TickRate     int  `json:"tick_rate"`
+	MaxFrameSkip int  `json:"max_frame_skip"`
+	MaxElapsedMS int  `json:"max_elapsed_ms"`
+
+	Background string `json:"background"` // palette name or #rrggbb[aa]
+	Audio      *bool  `json:"audio"`
+
+	Batching   bool   `json:"batching"`
+	Resizable  bool   `json:"resizable"`
+	Fullscreen bool   `json:"fullscreen"`
+	VSync      bool   `json:"vsync"`
+	HighDPI    bool   `json:"high_dpi"`
+	MinWidth   int    `json:"min_width"`
+	MinHeight  int    `json:"min_height"`
+	Icon       string `json:"icon"`
+}
+
+// LoadConfig reads a Config through the loader search paths.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := loader.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("runt: config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Options converts the config into Engine options.
+func (c Config) Options() ([]Option, error) {
+	var opts []Option
+	if c.Title != "" {
+		opts = append(opts, WithTitle(c.Title))
+	}
+	if c.Width > 0 && c.Height > 0 {
+		opts = append(opts, WithSize(c.Width, c.Height))
+	}
+	if c.VirtualWidth > 0 && c.VirtualHeight > 0 {
+		mode, err := parseScaleMode(c.Scale)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithVirtualResolution(c.VirtualWidth, c.VirtualHeight, mode))
+	}
+	if c.FPS != nil {
+		opts = append(opts, WithFPS(*c.FPS))
+	}
+	if c.TickRate > 0 {
+		opts = append(opts, WithTickRate(c.TickRate))
+	}
+	if c.MaxFrameSkip > 0 {
+		opts = append(opts, WithMaxFrameSkip(c.MaxFrameSkip))
+	}
+	if c.MaxElapsedMS > 0 {
+		opts = append(opts, WithMaxElapsed(time.Duration(c.MaxElapsedMS)*time.Millisecond))
+	}
+	if c.Background != "" {
+		col, ok := graphics.NamedColor(c.Background)
+		if !ok {
+			return nil, fmt.Errorf("runt: config: unknown background color %q", c.Background)
+		}
+		opts = append(opts, WithBackground(col))
+	}
+	if c.Audio != nil {
+		opts = append(opts, WithAudio(*c.Audio))
+	}
+	if c.Batching {
+		opts = append(opts, WithBatching())
+	}
+	if c.Resizable {
+		opts = append(opts, WithResizable())
+	}
+	if c.Fullscreen {
+		opts = append(opts, WithFullscreen())
+	}
+	if c.VSync {
+		opts = append(opts, WithVSync(true))
+	}
+	if c.HighDPI {
+		opts = append(opts, WithHighDPI())
+	}
+	if c.MinWidth > 0 || c.MinHeight > 0 {
+		opts = append(opts, WithMinSize(c.MinWidth, c.MinHeight))
+	}
+	if c.Icon != "" {
+		opts = append(opts, WithIcon(c.Icon))
+	}
+	return opts, nil
+}
+
+// WithConfig applies every option set in c.  Invalid values panic; use
+// Config.Options to handle them as errors.
+func WithConfig(c Config) Option {
+	opts, err := c.Options()
+	if err != nil {
+		panic(err)
+	}
+	return func(e *Engine) {
+		for _, opt := range opts {
+			opt(e)
+		}
+	}
+}
+
+// WithConfigFile loads a JSON Config through the loader and applies it.
+// Panics if the file is missing or invalid, like the loader does for
+// other assets.  Options after it override the file.
+func WithConfigFile(path string) Option {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		panic(err)
+	}
+	return WithConfig(cfg)
+}
+
+func parseScaleMode(s string) (ScaleMode, error) {
+	switch strings.ToLower(s) {
+	case "", "integer":
+		return ScaleInteger, nil
+	case "fit":
+		return ScaleFit, nil
+	case "none":
+		return ScaleNone, nil
+	}
+	return ScaleNone, fmt.Errorf("runt: config: unknown scale mode %q", s)
+}
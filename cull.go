@@ -0,0 +1,389 @@
+// runt/cull.go
+package runt
+
+import (
+	"math"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
+)
+
+// -----------------------------------------------------------------------------
+// Render bounds & culling
+// -----------------------------------------------------------------------------
+
+// Bounded is implemented by entities that know where they draw.  r is in
+// screen space for the camera offset (camX,camY) before zoom/rotation; ok
+// is false when unknown, and such entities are never culled.
+type Bounded interface {
+	RenderBounds(camX, camY float32) (r rl.Rectangle, ok bool)
+}
+
+// RenderBounds implements Bounded from the Graphic (see graphics.Bounder)
+// or, failing that, the hitbox.
+func (e *BaseEntity) RenderBounds(camX, camY float32) (rl.Rectangle, bool) {
+	if !e.Visible || e.Graphic == nil || !e.Graphic.IsVisible() {
+		return rl.Rectangle{}, true // draws nothing, so nothing to see
+	}
+	x, y := e.drawPosition()
+	if b, ok := e.Graphic.(graphics.Bounder); ok {
+		if p, ok := e.Graphic.(graphics.Positioner); ok {
+			p.SetPosition(x, y)
+		}
+		if r, ok := b.Bounds(camX, camY); ok {
+			return r, true
+		}
+	}
+	if e.hitboxWidth > 0 && e.hitboxHeight > 0 {
+		return rl.NewRectangle(x+e.hitboxX-camX, y+e.hitboxY-camY, e.hitboxWidth, e.hitboxHeight), true
+	}
+	return rl.Rectangle{}, false
+}
+
+// CullStats counts what the last World.Render did, summed over views.
+type CullStats struct {
+	Entities  int // considered
+	Drawn     int
+	Culled    int
+	Unbounded int // drawn because they have no bounds
+	Queried   bool
+}
+
+// CullStats returns the counts from the last Render.
+func (w *World) CullStats() CullStats { return w.cullStats }
+
+// cullRect is the area a camera shows, in the screen space RenderBounds
+// uses, grown by margin.  Rotation and shake widen it to a safe square.
+func (c *Camera) cullRect(margin float32) rl.Rectangle {
+	hw, hh := c.half()
+	zoom := c.Zoom
+	if zoom <= 0 {
+		zoom = 1
+	}
+	ew, eh := hw/zoom, hh/zoom
+	if c.Rotation+c.shakeA != 0 {
+		r := float32(math.Hypot(float64(ew), float64(eh)))
+		ew, eh = r, r
+	}
+	ew += margin + abs32(c.shakeX)
+	eh += margin + abs32(c.shakeY)
+	return rl.NewRectangle(hw-ew, hh-eh, 2*ew, 2*eh)
+}
+
+// abs32 is math.Abs for float32.
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// overlaps reports whether two rectangles intersect.  Pure Go, so it is
+// cheap per entity.
+func overlaps(a, b rl.Rectangle) bool {
+	return a.X < b.X+b.Width && b.X < a.X+a.Width &&
+		a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+}
+
+// renderLayers draws the layers v shows (all for nil) through cam, culling
+// off-screen entities when w.Culling is on.
+func (w *World) renderLayers(cam *Camera, v *View) {
+	var view rl.Rectangle
+	var cx, cy float32
+	broad := false
+	if w.Culling {
+		view = cam.cullRect(w.CullMargin)
+		cx, cy = cam.View()
+		if w.broad != nil {
+			w.syncBroadphase()
+			w.pass++
+			world := view
+			world.X += cx
+			world.Y += cy
+			w.broad.Query(world, func(e Entity) { w.seen[e] = w.pass })
+			broad = true
+			w.cullStats.Queried = true
+		}
+	}
+
+	for _, layer := range w.layerOrder {
+		if v != nil && !v.Shows(layer) {
+			continue
+		}
+		graphics.SetBatchLayer(layer)
+		for _, e := range w.layers[layer] {
+			w.cullStats.Entities++
+			if w.Culling {
+				visible, bounded := w.inView(e, view, cx, cy, broad)
+				if !bounded {
+					w.cullStats.Unbounded++
+				} else if !visible {
+					w.cullStats.Culled++
+					continue
+				}
+			}
+			graphics.BatchGroup()
+			e.Render()
+			w.cullStats.Drawn++
+		}
+	}
+}
+
+// inView tests e against the view rectangle, through the broadphase
+// results when there are some.
+func (w *World) inView(e Entity, view rl.Rectangle, cx, cy float32, broad bool) (visible, bounded bool) {
+	if broad {
+		if _, ok := w.indexed[e]; !ok {
+			return true, false
+		}
+		return w.seen[e] == w.pass, true
+	}
+	b, ok := e.(Bounded)
+	if !ok {
+		return true, false
+	}
+	r, ok := b.RenderBounds(cx, cy)
+	if !ok {
+		return true, false
+	}
+	return overlaps(r, view), true
+}
+
+// -----------------------------------------------------------------------------
+// Broadphase
+// -----------------------------------------------------------------------------
+
+// Broadphase is a spatial index of entity bounds in world coordinates.
+// The World keeps it up to date from RenderBounds: entities are inserted
+// as they join, removed as they leave and re-inserted after they move,
+// before culling or Query uses it.  BaseEntity reports its own moves
+// (MoveBy, MoveCollide, SetPosition); call World.Moved for other entities,
+// or when a graphic's bounds change without the entity moving.  Bounds
+// are taken with a zero camera, so parallax (ScrollX/Y ≠ 1) entities are
+// indexed where ScrollX/Y = 1 would put them; leave those unbounded or on
+// their own layer if that matters.
+type Broadphase interface {
+	Clear()
+	// Insert adds e, or moves it if it is already indexed.
+	Insert(e Entity, r rl.Rectangle)
+	Remove(e Entity)
+	Query(r rl.Rectangle, fn func(Entity))
+}
+
+// SetBroadphase installs (or with nil removes) the world's spatial index.
+func (w *World) SetBroadphase(b Broadphase) {
+	w.broad = b
+	w.broadDirty = true
+}
+
+// Broadphase returns the world's spatial index, or nil.
+func (w *World) Broadphase() Broadphase { return w.broad }
+
+// Query calls fn for every bounded entity whose bounds overlap the world
+// rectangle r.  With a broadphase installed fn may also see near misses;
+// without one every entity is tested.
+func (w *World) Query(r rl.Rectangle, fn func(Entity)) {
+	if w.broad != nil {
+		w.syncBroadphase()
+		w.broad.Query(r, fn)
+		return
+	}
+	w.ForEach(func(e Entity) {
+		if b, ok := e.(Bounded); ok {
+			if br, ok := b.RenderBounds(0, 0); ok && overlaps(br, r) {
+				fn(e)
+			}
+		}
+	})
+}
+
+// Moved tells the broadphase that e's bounds may have changed, so it is
+// re-indexed before the next cull or Query.
+func (w *World) Moved(e Entity) {
+	if w.broad != nil {
+		w.moved[e] = struct{}{}
+	}
+}
+
+// syncBroadphase rebuilds the index after SetBroadphase and otherwise
+// re-indexes only the entities that moved.
+func (w *World) syncBroadphase() {
+	if w.broadDirty {
+		w.broadDirty = false
+		w.broad.Clear()
+		clear(w.indexed)
+		clear(w.seen)
+		clear(w.moved)
+		for _, layer := range w.layerOrder {
+			for _, e := range w.layers[layer] {
+				w.index(e)
+			}
+		}
+		return
+	}
+	for e := range w.moved {
+		w.index(e)
+	}
+	clear(w.moved)
+}
+
+// index brings e's entry in the broadphase in line with its bounds.
+func (w *World) index(e Entity) {
+	b, ok := e.(Bounded)
+	if !ok {
+		return
+	}
+	r, ok := b.RenderBounds(0, 0)
+	old, in := w.indexed[e]
+	switch {
+	case !ok:
+		if in {
+			w.unindex(e)
+		}
+	case !in || old != r:
+		w.broad.Insert(e, r)
+		w.indexed[e] = r
+	}
+}
+
+// unindex drops e from the broadphase.
+func (w *World) unindex(e Entity) {
+	delete(w.moved, e)
+	if _, in := w.indexed[e]; in {
+		w.broad.Remove(e)
+		delete(w.indexed, e)
+		delete(w.seen, e)
+	}
+}
+
+// member is implemented by BaseEntity, so an entity knows the World it is
+// in and can report its moves to the broadphase.
+type member interface {
+	attach(w *World, self Entity)
+	detach(w *World)
+}
+
+// attach records the world e (embedded in self) was added to.
+func (e *BaseEntity) attach(w *World, self Entity) { e.world, e.self = w, self }
+
+// detach forgets w unless e has already joined another world.
+func (e *BaseEntity) detach(w *World) {
+	if e.world == w {
+		e.world, e.self = nil, nil
+	}
+}
+
+// moved reports a position change to the world's broadphase.
+func (e *BaseEntity) moved() {
+	if e.world != nil {
+		e.world.Moved(e.self)
+	}
+}
+
+// SpatialHash is a uniform-grid Broadphase: each entity is listed in every
+// cell its bounds touch.  Pick a cell size around the size of a typical
+// entity or a bit larger.
+type SpatialHash struct {
+	CellSize float32
+
+	cells map[[2]int32][]Entity
+	spans map[Entity][4]int32 // cell range each entity is listed in
+	stamp map[Entity]uint32
+	query uint32
+}
+
+// NewSpatialHash returns an empty hash with the given cell size.
+func NewSpatialHash(cellSize float32) *SpatialHash {
+	return &SpatialHash{
+		CellSize: max(cellSize, 1),
+		cells:    make(map[[2]int32][]Entity),
+		spans:    make(map[Entity][4]int32),
+		stamp:    make(map[Entity]uint32),
+	}
+}
+
+// Clear empties every cell, keeping the memory for the next rebuild.
+func (h *SpatialHash) Clear() {
+	for k, list := range h.cells {
+		if len(list) == 0 {
+			delete(h.cells, k) // not touched since the last Clear
+			continue
+		}
+		clear(list)
+		h.cells[k] = list[:0]
+	}
+	clear(h.spans)
+	clear(h.stamp)
+}
+
+// Insert adds e to every cell r touches.  An entity already in the hash is
+// moved, which costs nothing while it stays within the same cells.
+func (h *SpatialHash) Insert(e Entity, r rl.Rectangle) {
+	c0, r0, c1, r1 := h.span(r)
+	span := [4]int32{c0, r0, c1, r1}
+	if old, ok := h.spans[e]; ok {
+		if old == span {
+			return
+		}
+		h.Remove(e)
+	}
+	h.spans[e] = span
+	for row := r0; row <= r1; row++ {
+		for col := c0; col <= c1; col++ {
+			k := [2]int32{col, row}
+			h.cells[k] = append(h.cells[k], e)
+		}
+	}
+}
+
+// Remove takes e out of every cell it is listed in.
+func (h *SpatialHash) Remove(e Entity) {
+	span, ok := h.spans[e]
+	if !ok {
+		return
+	}
+	delete(h.spans, e)
+	delete(h.stamp, e)
+	for row := span[1]; row <= span[3]; row++ {
+		for col := span[0]; col <= span[2]; col++ {
+			k := [2]int32{col, row}
+			list := h.cells[k]
+			i := slices.Index(list, e)
+			if i < 0 {
+				continue
+			}
+			last := len(list) - 1
+			list[i] = list[last]
+			list[last] = nil
+			h.cells[k] = list[:last]
+		}
+	}
+}
+
+// Query calls fn once for each entity in the cells r touches.
+func (h *SpatialHash) Query(r rl.Rectangle, fn func(Entity)) {
+	h.query++
+	c0, r0, c1, r1 := h.span(r)
+	for row := r0; row <= r1; row++ {
+		for col := c0; col <= c1; col++ {
+			for _, e := range h.cells[[2]int32{col, row}] {
+				if h.stamp[e] == h.query {
+					continue
+				}
+				h.stamp[e] = h.query
+				fn(e)
+			}
+		}
+	}
+}
+
+// span returns the inclusive cell range covered by r.
+func (h *SpatialHash) span(r rl.Rectangle) (c0, r0, c1, r1 int32) {
+	s := float64(h.CellSize)
+	c0 = int32(math.Floor(float64(r.X) / s))
+	r0 = int32(math.Floor(float64(r.Y) / s))
+	c1 = int32(math.Floor(float64(r.X+max(r.Width, 0)) / s))
+	r1 = int32(math.Floor(float64(r.Y+max(r.Height, 0)) / s))
+	return
+}
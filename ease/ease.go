@@ -0,0 +1,94 @@
+// Package ease holds easing functions (port of FlashPunk's Ease.as).  Each
+// maps t in [0,1] to an eased value, 0 at t=0 and 1 at t=1.
+package ease
+
+import "math"
+
+// Func is an easing function.  A nil Func is treated as Linear by callers.
+type Func func(t float64) float64
+
+// Apply runs f on t, treating a nil f as Linear.
+func (f Func) Apply(t float64) float64 {
+	if f == nil {
+		return t
+	}
+	return f(t)
+}
+
+// Linear returns t unchanged.
+func Linear(t float64) float64 { return t }
+
+// Quadratic
+func QuadIn(t float64) float64  { return t * t }
+func QuadOut(t float64) float64 { return -t * (t - 2) }
+func QuadInOut(t float64) float64 {
+	if t <= .5 {
+		return t * t * 2
+	}
+	t--
+	return 1 - t*t*2
+}
+
+// Cubic
+func CubicIn(t float64) float64  { return t * t * t }
+func CubicOut(t float64) float64 { t--; return 1 + t*t*t }
+func CubicInOut(t float64) float64 {
+	if t <= .5 {
+		return t * t * t * 4
+	}
+	t--
+	return 1 + t*t*t*4
+}
+
+// Sine
+func SineIn(t float64) float64    { return 1 - math.Cos(t*math.Pi/2) }
+func SineOut(t float64) float64   { return math.Sin(t * math.Pi / 2) }
+func SineInOut(t float64) float64 { return -math.Cos(math.Pi*t)/2 + .5 }
+
+// Exponential
+func ExpoIn(t float64) float64 {
+	if t == 0 {
+		return 0
+	}
+	return math.Pow(2, 10*(t-1))
+}
+func ExpoOut(t float64) float64 {
+	if t == 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+// Back overshoots slightly before settling.
+const backOvershoot = 1.70158
+
+func BackIn(t float64) float64  { return t * t * ((backOvershoot+1)*t - backOvershoot) }
+func BackOut(t float64) float64 { t--; return 1 + t*t*((backOvershoot+1)*t+backOvershoot) }
+
+// ElasticOut springs past the target and oscillates back.
+func ElasticOut(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-.75)*(2*math.Pi/3)) + 1
+}
+
+// BounceOut bounces against the target like a dropped ball.
+func BounceOut(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + .75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + .9375
+	}
+	t -= 2.625 / d
+	return n*t*t + .984375
+}
+
+// BounceIn is BounceOut played backwards.
+func BounceIn(t float64) float64 { return 1 - BounceOut(1-t) }
@@ -6,12 +6,13 @@ import (
 	"time"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
 )
 
 // Game is your application’s entrypoint interface.
 //
 //	– Create()   is called once at startup.
-//	– Update(dt) is called one or more times per frame (dt = seconds).
+//	– Update(dt) is called once per frame, or once per fixed tick (dt = seconds × Rate).
 //	– Draw(interp) is called every frame; interp is 0–1 in fixed mode, always 0 in variable mode.
 type Game interface {
 	Create()
@@ -22,60 +23,112 @@ type Game interface {
 // Engine drives the window, main loop, timing and background.
 // It supports both fixed‐timestep (with interpolation) and variable‐timestep modes.
 type Engine struct {
-	game         Game          // the user’s Game implementation
-	title        string        // window title
-	fps          int           // desired frame rate (and tick rate in fixed mode)
-	bg           Color         // clear color for the backbuffer (alias for rl.Color)
-	fixed        bool          // true → fixed‐timestep + interpolation
-	tickRate     time.Duration // time per physics tick (1/fps)
-	maxElapsed   float64       // clamp on dt to avoid spiral-of-death
-	maxFrameSkip int           // max physics steps per frame
-	paused       bool          // when true, Update(dt) is skipped
+	game         Game            // the user’s Game implementation
+	title        string          // window title
+	fps          int             // target render frame rate (0 = uncapped)
+	bg           Color           // clear color for the backbuffer (alias for rl.Color)
+	fixed        bool            // true → fixed‐timestep + interpolation
+	tickRate     time.Duration   // time per physics tick in fixed mode
+	maxElapsed   float64         // clamp on dt to avoid spiral-of-death
+	maxFrameSkip int             // max physics steps per frame
+	paused       bool            // when true, Update(dt) is skipped
+	audio        bool            // open the audio device in Run
+	world        *World          // installed as CurrentWorld when Run starts
+	post         PostFX          // post-processing chain, idle until a pass is added
+	batch        *graphics.Batch // sprite batcher for Draw, nil = immediate
+
+	// virtual resolution (see screen.go)
+	width, height    int                // virtual size; 0 = same as the window
+	winW, winH       int                // window size in pixels
+	scaleMode        ScaleMode          // how Width×Height maps onto the window
+	letterbox        Color              // bar color around a scaled screen
+	target           rl.RenderTexture2D // virtual screen when scaling
+	targetW, targetH int
+	mouseMapped      bool // mouse offset/scale currently set for the viewport
+
+	// window options (see window.go)
+	resizable, vsync, highDPI bool
+	startFullscreen           bool
+	minW, minH                int
+	iconPath                  string
+	lastW, lastH              int // window size last reported to Resizer
 }
 
-// NewEngine constructs an Engine but does not open the window.
+// NewEngine constructs an Engine but does not open the window or touch
+// any package state; Run applies the configuration.  Without options it
+// opens a 640×360 window titled "runt", renders at 60 FPS and updates with
+// a variable timestep.
 //
-//	w, h   – window dimensions
-//	title  – window title
-//	fps    – target framerate (also physics tick rate in fixed mode)
-//	game   – your Game instance
-//	fixed  – whether to use fixed‐timestep + interpolation
-func NewEngine(
-	w, h int,
-	title string,
-	fps int,
-	game Game,
-	fixed bool,
-) *Engine {
-	// Configure our package-level state (screen size, FPS)
-	Resize(w, h)
-	AssignedFPS = fps
-
-	return &Engine{
+//	e := runt.NewEngine(game,
+//		runt.WithSize(1280, 720),
+//		runt.WithTitle("My Game"),
+//		runt.WithTickRate(60),
+//	)
+func NewEngine(game Game, opts ...Option) *Engine {
+	e := &Engine{
 		game:         game,
-		title:        title,
-		fps:          fps,
+		title:        "runt",
+		fps:          60,
 		bg:           BackgroundColor, // default from palette.go
-		fixed:        fixed,           // fixed vs variable
-		tickRate:     time.Second / time.Duration(fps),
+		fixed:        false,
+		tickRate:     time.Second / 60,
 		maxElapsed:   1.0 / 10.0, // clamp dt at 100ms
 		maxFrameSkip: 5,          // avoid too many physics steps
 		paused:       false,      // start unpaused
+		audio:        true,
+		winW:         640,
+		winH:         360,
+		letterbox:    rl.Black,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// virtualSize is the size Width/Height are set to.
+func (e *Engine) virtualSize() (int, int) {
+	if e.width > 0 && e.height > 0 {
+		return e.width, e.height
 	}
+	return e.winW, e.winH
 }
 
 // Run opens the window, initializes audio, and enters the main loop.
 // It handles timing, update, interpolation, and drawing.
 func (e *Engine) Run() {
+	// Configure our package-level state (screen size, FPS, world)
+	Resize(e.virtualSize())
+	Fixed = e.fixed
+	if e.world != nil {
+		CurrentWorld = e.world
+	}
+
 	// --- Initialize Raylib ---
-	rl.InitWindow(int32(Width), int32(Height), e.title)
+	rl.SetConfigFlags(e.windowFlags())
+	rl.InitWindow(int32(e.winW), int32(e.winH), e.title)
 	defer rl.CloseWindow()
-	rl.InitAudioDevice()
-	defer rl.CloseAudioDevice()
+	e.initWindow()
+	if e.fps == FPSMonitor {
+		e.fps = rl.GetMonitorRefreshRate(rl.GetCurrentMonitor())
+		if e.fps <= 0 {
+			e.fps = 60
+		}
+	}
+	AssignedFPS = e.fps
+	defer e.post.Unload()
+	defer e.unloadVirtual()
+	if e.audio {
+		rl.InitAudioDevice()
+		defer rl.CloseAudioDevice()
+	}
 	rl.SetTargetFPS(int32(e.fps))
 
 	// Let the Game set itself up.
 	e.game.Create()
+	if CurrentWorld != nil {
+		CurrentWorld.Snapshot() // start interpolation from the initial state
+	}
 
 	// Buffer for dt statistics.
 	const sampleCount = 120
@@ -125,50 +178,62 @@ func (e *Engine) Run() {
 			dts = dts[:0]
 		}
 
+		// Tell the Game if the window changed size.
+		e.checkResize()
+
 		// Apply any global time‐scale.
 		Elapsed = dt * Rate
+		RealElapsed = dt
 
 		// ---- 2) Update ----
 		if !e.paused {
 			if e.fixed {
-				// Fixed‐timestep mode.
-				lag += dt
+				// Fixed‐timestep mode: run whole ticks out of the
+				// accumulator.  Each World.Update snapshots its world
+				// first so the render can blend the last two states.
 				step := e.tickRate.Seconds()
-
-				// Cap physics steps.
-				if lag > step*float64(e.maxFrameSkip) {
-					lag = step * float64(e.maxFrameSkip)
-				}
-
-				// Snapshot all entities for interpolation.
-				for _, ent := range CurrentWorld.Entities() {
-					if s, ok := ent.(interface{ Snapshot() }); ok {
-						s.Snapshot()
-					}
-				}
-
-				// Run fixed‐size physics steps.
-				for lag >= step {
-					e.game.Update(step)
+				lag += dt
+				steps := 0
+				for lag >= step && steps < e.maxFrameSkip {
+					Elapsed = step * Rate
+					RealElapsed = step
+					e.game.Update(Elapsed)
 					lag -= step
+					steps++
+				}
+				// Too far behind: drop the backlog rather than spiral.
+				if lag >= step {
+					lag = math.Mod(lag, step)
 				}
 			} else {
 				// Variable‐timestep mode.
-				e.game.Update(dt)
+				e.game.Update(Elapsed)
 			}
 		}
 
 		// ---- 3) Render ----
 		rl.BeginDrawing()
+		post := e.post.Active()
+		virtual := e.beginVirtual(post)
+		if post {
+			e.post.begin(Width, Height)
+		}
 		rl.ClearBackground(e.bg) // Color is our alias for rl.Color
 
-		// Camera transform.
-		camX, camY := CurrentWorld.CameraX, CurrentWorld.CameraY
-		rl.BeginMode2D(rl.NewCamera2D(
-			rl.Vector2{X: camX, Y: camY},
-			rl.Vector2{X: 0, Y: 0},
-			0, 1,
-		))
+		// Camera transform: zoom, rotation and shake around the screen
+		// centre.  Graphics apply the view offset themselves (with
+		// parallax), so it is not part of the Camera2D; the view rectangle
+		// tells Tilemaps and Backdrops how much of the world it shows.
+		cam := rl.NewCamera2D(rl.Vector2{}, rl.Vector2{}, 0, 1)
+		if CurrentWorld != nil {
+			cam = CurrentWorld.Camera.Camera2D()
+			graphics.SetViewRect(CurrentWorld.Camera.cullRect(0))
+		}
+		rl.BeginMode2D(cam)
+		frameCamera = &cam
+		if e.batch != nil {
+			graphics.BeginBatch(e.batch)
+		}
 
 		// Draw with interpolation factor.
 		if e.fixed {
@@ -180,7 +245,15 @@ func (e *Engine) Run() {
 			e.game.Draw(0)
 		}
 
+		graphics.EndBatch()
+		graphics.SetViewRect(rl.Rectangle{})
+		frameCamera = nil
 		rl.EndMode2D()
+		if post {
+			e.post.end(viewport, e.letterbox)
+		} else if virtual {
+			e.endVirtual()
+		}
 		rl.EndDrawing()
 
 		// ---- 4) FPS ----
@@ -188,6 +261,24 @@ func (e *Engine) Run() {
 	}
 }
 
+// PostFX returns the post-processing chain.  Add passes from Game.Create
+// (shaders need the window to exist):
+//
+//	e.PostFX().Add(runt.NewScanlinesPass(0.3))
+//	e.PostFX().Add(runt.NewPalettePass(nil))
+func (e *Engine) PostFX() *PostFX {
+	return &e.post
+}
+
+// Batch returns the sprite batcher, or nil without WithBatching.  Its
+// Stats describe the last frame:
+//
+//	s := e.Batch().Stats()
+//	rl.DrawText(fmt.Sprintf("%d sprites, %d calls", s.Sprites, s.DrawCalls), 4, 4, 10, rl.White)
+func (e *Engine) Batch() *graphics.Batch {
+	return e.batch
+}
+
 // SetBackground updates the clear color at runtime.
 func (e *Engine) SetBackground(c Color) {
 	e.bg = c
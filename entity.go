@@ -5,8 +5,9 @@ import (
 	"github.com/henrypekny/runt/mask"
 )
 
-// Interp is the current interpolation factor (0–1).
-// The Engine sets this each frame before drawing.
+// Interp is the current interpolation factor (0–1): how far the render
+// sits between the last two fixed ticks.  The Engine sets this each frame
+// before drawing.
 var Interp float32
 
 // BaseEntity provides position, layer, visibility, a Graphic,
@@ -20,6 +21,14 @@ type BaseEntity struct {
 	// prevRawPosition is used for interpolation.
 	prevRawX, prevRawY float32
 
+	// extra fields blended between ticks (see interp.go)
+	interp InterpSet
+
+	// own clock (see timescale.go)
+	timeScale    float64
+	timeScaleSet bool
+	hitstop      int
+
 	// LayerID is the rendering layer.
 	LayerID int
 
@@ -35,6 +44,11 @@ type BaseEntity struct {
 	// Hitbox dimensions & offset, for mask.Parent methods.
 	hitboxX, hitboxY          float32
 	hitboxWidth, hitboxHeight float32
+
+	// the World we were added to and the Entity embedding us, so moves
+	// reach its broadphase (see cull.go)
+	world *World
+	self  Entity
 }
 
 // NewBaseEntity creates one at (x,y) on the given layer.
@@ -52,11 +66,13 @@ func NewBaseEntity(x, y float32, layer int) *BaseEntity {
 	}
 }
 
-// Snapshot stores the current rawX/rawY into prevRawX/prevRawY.
-// Called by Engine once per physics tick before Update().
+// Snapshot stores the current rawX/rawY into prevRawX/prevRawY, along
+// with any fields registered through Interpolate.
+// Called by World.Update before every fixed tick.
 func (e *BaseEntity) Snapshot() {
 	e.prevRawX = e.rawX
 	e.prevRawY = e.rawY
+	e.interp.Snapshot()
 }
 
 // Update advances any graphic animations (but not movement).
@@ -66,38 +82,40 @@ func (e *BaseEntity) Update(dt float64) {
 	}
 }
 
+// drawPosition is the position to draw at this frame: interpolated between
+// the last two ticks in fixed mode, the current one otherwise.
+func (e *BaseEntity) drawPosition() (float32, float32) {
+	if Fixed {
+		return e.prevRawX + (e.rawX-e.prevRawX)*Interp,
+			e.prevRawY + (e.rawY-e.prevRawY)*Interp
+	}
+	return e.rawX, e.rawY
+}
+
 // Render snaps to integer pixels and draws the Graphic.
-// If Interp>0 we interpolate between prev and current.
+// In fixed mode we interpolate between prev and current by Interp.
 func (e *BaseEntity) Render() {
 	// nothing to draw?
 	if !e.Visible || e.Graphic == nil || !e.Graphic.IsVisible() {
 		return
 	}
 
-	// camera offset
-	cx, cy := CurrentWorld.CameraX, CurrentWorld.CameraY
-
-	// choose interpolated or direct position
-	var drawX, drawY float32
-	if Interp > 0 {
-		drawX = e.prevRawX + (e.rawX-e.prevRawX)*Interp
-		drawY = e.prevRawY + (e.rawY-e.prevRawY)*Interp
-	} else {
-		drawX = e.rawX
-		drawY = e.rawY
+	// camera offset of the world (and view) drawing us
+	var cx, cy float32
+	if cam := DrawingCamera(); cam != nil {
+		cx, cy = cam.View()
 	}
 
-	// if it's an Image, push our computed drawX/drawY into it
-	switch g := e.Graphic.(type) {
-	case *graphics.Image:
-		g.X = drawX
-		g.Y = drawY
-	case *graphics.Text:
-		g.SetPosition(drawX, drawY)
+	// push our interpolated position into positional graphics
+	drawX, drawY := e.drawPosition()
+	if p, ok := e.Graphic.(graphics.Positioner); ok {
+		p.SetPosition(drawX, drawY)
 	}
 
-	// finally draw it
+	// finally draw it, with any extra fields blended for this frame
+	e.interp.Apply()
 	e.Graphic.Render(cx, cy)
+	e.interp.Restore()
 }
 
 // Layer implements the runt.Entity interface.
@@ -146,9 +164,94 @@ func (e *BaseEntity) SetHitbox(w, h, ox, oy float32) {
 	e.hitboxHeight = h
 }
 
-// MoveBy is a helper to adjust rawX/rawY; you can implement your own
-// collision‐aware move logic here.
+// SetMask installs any collision mask (Hitbox, Grid, …) and makes this
+// entity its parent.  A level entity typically uses a mask.Grid built from
+// its Tilemap so other entities can MoveCollide against the terrain.
+func (e *BaseEntity) SetMask(m mask.Mask) {
+	if m != nil {
+		m.SetParent(e)
+	}
+	e.Mask = m
+}
+
+// Solid is anything with a collision mask that can block movement.
+// Every type embedding BaseEntity satisfies it.
+type Solid interface {
+	CollisionMask() mask.Mask
+}
+
+// CollisionMask implements Solid.
+func (e *BaseEntity) CollisionMask() mask.Mask {
+	return e.Mask
+}
+
+// MoveBy is a helper to adjust rawX/rawY without any collision checks;
+// see MoveCollide for the collision‐aware variant.
 func (e *BaseEntity) MoveBy(dx, dy float32) {
 	e.rawX += dx
 	e.rawY += dy
+	e.moved()
+}
+
+// SetPosition moves straight to (x,y).  Follow it with ResetInterpolation
+// for a teleport.
+func (e *BaseEntity) SetPosition(x, y float32) {
+	e.rawX, e.rawY = x, y
+	e.moved()
+}
+
+// CollideAt reports the first solid whose mask overlaps ours as if this
+// entity stood at (x,y), or nil.  Solids without a mask, and the entity
+// itself, are skipped.
+func (e *BaseEntity) CollideAt(x, y float32, solids ...Solid) Solid {
+	if e.Mask == nil {
+		return nil
+	}
+	ox, oy := e.rawX, e.rawY
+	e.rawX, e.rawY = x, y
+	defer func() { e.rawX, e.rawY = ox, oy }()
+
+	for _, s := range solids {
+		m := s.CollisionMask()
+		if m == nil || m == e.Mask {
+			continue
+		}
+		if e.Mask.Collide(m) {
+			return s
+		}
+	}
+	return nil
+}
+
+// MoveCollide moves by (dx,dy) one pixel at a time, X axis first, and stops
+// each axis just before it would overlap any of the solids.  It reports
+// which axes were blocked.
+func (e *BaseEntity) MoveCollide(dx, dy float32, solids ...Solid) (blockedX, blockedY bool) {
+	if e.Mask == nil || len(solids) == 0 {
+		e.MoveBy(dx, dy)
+		return false, false
+	}
+	blockedX = e.moveAxis(&e.rawX, dx, solids)
+	blockedY = e.moveAxis(&e.rawY, dy, solids)
+	e.moved()
+	return
+}
+
+// moveAxis steps *pos towards *pos+d in increments of at most one pixel.
+func (e *BaseEntity) moveAxis(pos *float32, d float32, solids []Solid) bool {
+	for d != 0 {
+		step := d
+		if step > 1 {
+			step = 1
+		} else if step < -1 {
+			step = -1
+		}
+		*pos += step
+		if e.CollideAt(e.rawX, e.rawY, solids...) != nil {
+			*pos -= step
+			return true
+		}
+		d -= step
+	}
+	return false
 }
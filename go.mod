@@ -2,7 +2,10 @@ module github.com/henrypekny/runt
 
 go 1.24.1
 
-require github.com/gen2brain/raylib-go/raylib v0.0.0-20250409052854-a4292f0f0412
+require (
+	github.com/gen2brain/raylib-go/raylib v0.0.0-20250409052854-a4292f0f0412
+	github.com/klauspost/compress v1.18.0
+)
 
 require (
 	github.com/ebitengine/purego v0.7.1 // indirect
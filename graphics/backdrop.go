@@ -0,0 +1,125 @@
+// runt/graphics/backdrop.go
+package graphics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// Backdrop repeats a texture across the whole view on X and/or Y, for any
+// camera position and parallax factor.  Stack several with different
+// ScrollX/ScrollY to build parallax skies.
+type Backdrop struct {
+	Texture rl.Texture2D
+
+	// Offset of one tile's top-left corner in world space
+	X, Y float32
+
+	// Parallax scrolling factors (1 == follow camera, 0 == fixed to screen)
+	ScrollX, ScrollY float32
+
+	// Which axes repeat; a non-repeating axis draws a single row/column
+	RepeatX, RepeatY bool
+
+	// Auto-scroll velocity in pixels per second
+	VelocityX, VelocityY float32
+
+	// Uniform scale of each tile
+	Scale float32
+
+	// Tint color & alpha override
+	Color rl.Color
+
+	// accumulated auto-scroll, kept within one tile
+	driftX, driftY float32
+
+	visible bool
+}
+
+// NewBackdrop loads `path` through the loader and repeats it on the given axes.
+func NewBackdrop(path string, repeatX, repeatY bool) *Backdrop {
+	return NewBackdropFromTexture(loader.LoadTexture(path), repeatX, repeatY)
+}
+
+// NewBackdropFromTexture repeats an existing texture on the given axes.
+func NewBackdropFromTexture(tex rl.Texture2D, repeatX, repeatY bool) *Backdrop {
+	rl.SetTextureFilter(tex, rl.FilterPoint)
+	return &Backdrop{
+		Texture: tex,
+		ScrollX: 1, ScrollY: 1,
+		RepeatX: repeatX,
+		RepeatY: repeatY,
+		Scale:   1,
+		Color:   rl.White,
+		visible: true,
+	}
+}
+
+// Update advances the auto-scroll.
+func (b *Backdrop) Update(dt float64) {
+	w, h := b.tileSize()
+	b.driftX = wrap(b.driftX+b.VelocityX*float32(dt), w)
+	b.driftY = wrap(b.driftY+b.VelocityY*float32(dt), h)
+}
+
+// IsVisible reports current visibility.
+func (b *Backdrop) IsVisible() bool { return b.visible }
+
+// SetVisible toggles drawing.
+func (b *Backdrop) SetVisible(v bool) { b.visible = v }
+
+// SetPosition moves the tiling origin to (x,y).
+func (b *Backdrop) SetPosition(x, y float32) { b.X, b.Y = x, y }
+
+// Render covers the view with copies of the texture.
+func (b *Backdrop) Render(camX, camY float32) {
+	if !b.visible {
+		return
+	}
+	w, h := b.tileSize()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	v := ViewRect()
+
+	// screen position of one tile, then pulled back to just left/above
+	// the view on repeating axes
+	x0 := b.X + b.driftX - camX*b.ScrollX
+	y0 := b.Y + b.driftY - camY*b.ScrollY
+	x1, y1 := x0+w, y0+h
+	if b.RepeatX {
+		x0 = v.X + wrap(x0-v.X, w) - w
+		x1 = v.X + v.Width
+	}
+	if b.RepeatY {
+		y0 = v.Y + wrap(y0-v.Y, h) - h
+		y1 = v.Y + v.Height
+	}
+
+	src := rl.NewRectangle(0, 0, float32(b.Texture.Width), float32(b.Texture.Height))
+	for y := y0; y < y1; y += h {
+		for x := x0; x < x1; x += w {
+			dst := rl.NewRectangle(float32(math.Floor(float64(x))), float32(math.Floor(float64(y))), w, h)
+			DrawSprite(Sprite{Texture: b.Texture, Src: src, Dst: dst, Color: b.Color})
+		}
+	}
+}
+
+// tileSize is the on-screen size of one copy of the texture.
+func (b *Backdrop) tileSize() (float32, float32) {
+	return float32(b.Texture.Width) * b.Scale, float32(b.Texture.Height) * b.Scale
+}
+
+// wrap maps v into [0,size).
+func wrap(v, size float32) float32 {
+	if size <= 0 {
+		return v
+	}
+	v = float32(math.Mod(float64(v), float64(size)))
+	if v < 0 {
+		v += size
+	}
+	return v
+}
@@ -0,0 +1,283 @@
+// runt/graphics/batch.go
+package graphics
+
+import (
+	"cmp"
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// -----------------------------------------------------------------------------
+// Sprite batching
+// -----------------------------------------------------------------------------
+
+// Sprite is one textured quad, the unit a Batch sorts and draws.  The
+// fields mirror rl.DrawTexturePro.
+type Sprite struct {
+	Layer    int
+	Texture  rl.Texture2D
+	Shader   *Shader      // nil = default
+	Blend    rl.BlendMode // rl.BlendAlpha (the zero value) = default
+	Src, Dst rl.Rectangle
+	Origin   rl.Vector2
+	Rotation float32
+	Color    rl.Color
+
+	key stateKey // state of the first sprite of its group
+	seq int      // submission order, the final sort key
+}
+
+// stateKey is the GPU state a sprite needs; sprites sharing it draw in
+// one call.
+type stateKey struct {
+	tex, shader uint32
+	blend       rl.BlendMode
+}
+
+// BatchStats counts the work of one frame (BeginBatch to EndBatch).
+// DrawCalls is the number of state changes the GPU had to flush at.
+type BatchStats struct {
+	Sprites      int
+	DrawCalls    int
+	TextureSwaps int
+	ShaderSwaps  int
+	BlendSwaps   int
+	Flushes      int
+}
+
+// Batch collects the sprites drawn between BeginBatch and EndBatch and
+// draws them with as few state changes as possible.  Sprites are grouped
+// (World.Render starts a group per entity, see BatchGroup); within a layer
+// whole groups are sorted by the texture, shader and blend mode of their
+// first sprite, but a group's own sprites are never reordered, so a
+// Tilemap's layers or a Graphiclist's children stack as drawn.  Graphics
+// that can't be batched (Text, raw raylib calls…) flush it first so they
+// stay in order; call FlushBatch yourself before drawing straight with
+// raylib.
+type Batch struct {
+	// KeepOrder sorts by layer only, so groups within a layer stack in
+	// the order they were drawn.
+	KeepOrder bool
+
+	// Headless sorts and counts without calling raylib, so draws can be
+	// checked without a window.  Usually combined with Record.
+	Headless bool
+
+	// Record appends every flushed sprite to Recorded in draw order.
+	Record   bool
+	Recorded []Sprite
+
+	queue []Sprite
+	layer int
+	key   stateKey
+	keyed bool // key holds the current group's first sprite
+	cur   BatchStats
+	last  BatchStats
+}
+
+// NewBatch returns an empty batch.
+func NewBatch() *Batch { return &Batch{} }
+
+// active is the batch sprites go to; nil draws immediately.
+var active *Batch
+
+// BeginBatch routes sprite drawing into b until EndBatch.
+func BeginBatch(b *Batch) {
+	if active != nil {
+		active.Flush()
+	}
+	active = b
+	b.layer = 0
+	b.keyed = false
+	b.cur = BatchStats{}
+}
+
+// EndBatch draws whatever is pending and goes back to immediate drawing.
+// The frame's counts become the batch's Stats.
+func EndBatch() {
+	if active == nil {
+		return
+	}
+	active.Flush()
+	active.last = active.cur
+	active = nil
+}
+
+// ActiveBatch returns the batch being filled, or nil.
+func ActiveBatch() *Batch { return active }
+
+// FlushBatch draws the pending sprites of the active batch, if any.
+func FlushBatch() {
+	if active != nil {
+		active.Flush()
+	}
+}
+
+// SetBatchLayer sets the layer stamped on sprites added from now on and
+// starts a new group.  World.Render sets it per entity layer.
+func SetBatchLayer(layer int) {
+	if active != nil {
+		active.layer = layer
+		active.keyed = false
+	}
+}
+
+// BatchGroup starts a new group of sprites in the active batch.  Sprites
+// of one group keep their submission order; the batch only moves whole
+// groups to cut state changes.  World.Render calls it before each entity.
+func BatchGroup() {
+	if active != nil {
+		active.keyed = false
+	}
+}
+
+// suspendBatch flushes and detaches the active batch, for drawing into a
+// render texture; call the returned func to reattach it.
+func suspendBatch() func() {
+	b := active
+	if b == nil {
+		return func() {}
+	}
+	b.Flush()
+	active = nil
+	return func() { active = b }
+}
+
+// DrawSprite queues s in the active batch (on the current layer), or
+// draws it straight away when there is none.
+func DrawSprite(s Sprite) {
+	if active != nil {
+		active.Add(s)
+		return
+	}
+	if s.Shader != nil {
+		s.Shader.Begin()
+	}
+	if s.Blend != rl.BlendAlpha {
+		rl.BeginBlendMode(s.Blend)
+	}
+	rl.DrawTexturePro(s.Texture, s.Src, s.Dst, s.Origin, s.Rotation, s.Color)
+	if s.Blend != rl.BlendAlpha {
+		rl.EndBlendMode()
+	}
+	if s.Shader != nil {
+		s.Shader.End()
+	}
+}
+
+// Add queues s on the batch's current layer and group.
+func (b *Batch) Add(s Sprite) {
+	if !b.keyed {
+		b.key = stateKey{s.Texture.ID, shaderID(s.Shader), s.Blend}
+		b.keyed = true
+	}
+	s.Layer = b.layer
+	s.key = b.key
+	s.seq = len(b.queue)
+	b.queue = append(b.queue, s)
+}
+
+// Pending returns the number of queued sprites.
+func (b *Batch) Pending() int { return len(b.queue) }
+
+// Stats returns the counts of the last finished frame.
+func (b *Batch) Stats() BatchStats { return b.last }
+
+// Current returns the counts so far in the frame being drawn.
+func (b *Batch) Current() BatchStats { return b.cur }
+
+// ResetRecording empties Recorded.
+func (b *Batch) ResetRecording() { b.Recorded = b.Recorded[:0] }
+
+// Flush sorts and draws the queued sprites.
+func (b *Batch) Flush() {
+	if len(b.queue) == 0 {
+		return
+	}
+	slices.SortFunc(b.queue, b.compare)
+	b.cur.Flushes++
+
+	var tex uint32
+	var sh *Shader
+	blend := rl.BlendAlpha
+	for i := range b.queue {
+		s := &b.queue[i]
+		texChanged := i == 0 || s.Texture.ID != tex
+		shChanged := s.Shader != sh
+		blendChanged := s.Blend != blend
+		if texChanged || shChanged || blendChanged {
+			b.cur.DrawCalls++
+		}
+		if texChanged && i > 0 {
+			b.cur.TextureSwaps++
+		}
+		if shChanged {
+			b.cur.ShaderSwaps++
+		}
+		if blendChanged {
+			b.cur.BlendSwaps++
+		}
+
+		if !b.Headless {
+			if shChanged {
+				if sh != nil {
+					sh.End()
+				}
+				if s.Shader != nil {
+					s.Shader.Begin()
+				}
+			}
+			if blendChanged {
+				rl.BeginBlendMode(s.Blend)
+			}
+			rl.DrawTexturePro(s.Texture, s.Src, s.Dst, s.Origin, s.Rotation, s.Color)
+		}
+		tex, sh, blend = s.Texture.ID, s.Shader, s.Blend
+	}
+	if !b.Headless {
+		if sh != nil {
+			sh.End()
+		}
+		if blend != rl.BlendAlpha {
+			rl.EndBlendMode()
+		}
+	}
+
+	b.cur.Sprites += len(b.queue)
+	if b.Record {
+		b.Recorded = append(b.Recorded, b.queue...)
+	}
+	clear(b.queue)
+	b.queue = b.queue[:0]
+}
+
+// compare orders sprites by layer, then (unless KeepOrder) their group's
+// texture, shader and blend mode, then submission order.  A group's
+// sprites share the key and are contiguous in seq, so groups never
+// interleave.
+func (b *Batch) compare(x, y Sprite) int {
+	if c := cmp.Compare(x.Layer, y.Layer); c != 0 {
+		return c
+	}
+	if !b.KeepOrder {
+		if c := cmp.Compare(x.key.tex, y.key.tex); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(x.key.shader, y.key.shader); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(x.key.blend, y.key.blend); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(x.seq, y.seq)
+}
+
+// shaderID is the sort key for a shader; nil (the default) sorts first.
+func shaderID(s *Shader) uint32 {
+	if s == nil {
+		return 0
+	}
+	return s.Shader.ID
+}
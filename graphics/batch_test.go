@@ -0,0 +1,87 @@
+// runt/graphics/batch_test.go
+package graphics
+
+import (
+	"slices"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// fakeTexture is a texture handle that is never uploaded; headless
+// batches only look at its ID and size.
+func fakeTexture(id uint32) rl.Texture2D {
+	return rl.Texture2D{ID: id, Width: 8, Height: 8}
+}
+
+// testImage is an Image around a fake texture, built without raylib.
+func testImage(id uint32) *Image {
+	return &Image{
+		Texture: fakeTexture(id),
+		SrcRec:  rl.NewRectangle(0, 0, 8, 8),
+		ScaleX:  1, ScaleY: 1, Scale: 1,
+		ScrollX: 1, ScrollY: 1,
+		Color:   rl.White,
+		visible: true,
+	}
+}
+
+// recordFrame renders each graphic as its own batch group and returns the
+// texture IDs in the order the batch drew them.
+func recordFrame(t *testing.T, gs ...Graphic) []uint32 {
+	t.Helper()
+	ViewWidth, ViewHeight = 64, 64
+	defer func() { ViewWidth, ViewHeight = 0, 0 }()
+
+	b := NewBatch()
+	b.Headless = true
+	b.Record = true
+	BeginBatch(b)
+	for _, g := range gs {
+		BatchGroup()
+		g.Render(0, 0)
+	}
+	EndBatch()
+
+	ids := make([]uint32, len(b.Recorded))
+	for i, s := range b.Recorded {
+		ids[i] = s.Texture.ID
+	}
+	return ids
+}
+
+func TestBatchKeepsTilemapLayerOrder(t *testing.T) {
+	// the background layer uses the texture with the higher ID, so a
+	// texture sort would draw the foreground underneath it
+	tm := NewTilemapSize(8, 8, 2, 1)
+	tm.AddTilesetAt(&Tileset{Texture: fakeTexture(2), TileWidth: 8, TileHeight: 8, Columns: 1, Count: 1}, 0)
+	tm.AddTilesetAt(&Tileset{Texture: fakeTexture(1), TileWidth: 8, TileHeight: 8, Columns: 1, Count: 1}, 1)
+	bg, fg := tm.AddLayer("bg"), tm.AddLayer("fg")
+	bg.SetTile(0, 0, 0)
+	bg.SetTile(1, 0, 0)
+	fg.SetTile(0, 0, 1)
+	fg.SetTile(1, 0, 1)
+
+	got := recordFrame(t, tm)
+	if want := []uint32{2, 2, 1, 1}; !slices.Equal(got, want) {
+		t.Fatalf("draw order %v, want %v", got, want)
+	}
+}
+
+func TestBatchKeepsGraphiclistOrder(t *testing.T) {
+	a, b := testImage(1), testImage(2)
+	gl := NewGraphiclist(a, b)
+	gl.SendToBack(b)
+
+	got := recordFrame(t, gl)
+	if want := []uint32{2, 1}; !slices.Equal(got, want) {
+		t.Fatalf("draw order %v, want %v", got, want)
+	}
+}
+
+func TestBatchMergesGroupsByTexture(t *testing.T) {
+	got := recordFrame(t, testImage(2), testImage(1), testImage(2))
+	if want := []uint32{1, 2, 2}; !slices.Equal(got, want) {
+		t.Fatalf("draw order %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,353 @@
+// runt/graphics/canvas.go
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Canvas is a Graphic backed by a render texture that games draw into at
+// runtime: paint mechanics, minimaps, procedurally generated sprites.
+//
+// Drawing happens on the GPU; a CPU-side copy of the pixels is kept for
+// GetPixel, SetPixel, FloodFill and SavePNG and is only re-read from the
+// GPU when a draw call has made it stale.  Draw from Update, not Render:
+// texture mode replaces the active camera.
+type Canvas struct {
+	// World position of the top-left corner
+	X, Y float32
+
+	// Uniform draw scale
+	Scale float32
+
+	// Parallax scrolling factors (1 == follow camera exactly)
+	ScrollX, ScrollY float32
+
+	// Tint color & alpha override
+	Color rl.Color
+
+	target        rl.RenderTexture2D
+	width, height int
+
+	pixels   []color.RGBA // CPU mirror, row 0 at the top
+	scratch  []color.RGBA // row-flipped upload buffer
+	cpuStale bool         // GPU has draws the mirror hasn't seen
+	gpuStale bool         // mirror has edits the GPU hasn't seen
+	batching bool
+	resume   func() // reattaches a Batch suspended by Begin
+
+	visible bool
+}
+
+// NewCanvas creates a w×h canvas cleared to transparent.
+func NewCanvas(w, h int) *Canvas {
+	c := &Canvas{
+		Scale:   1,
+		ScrollX: 1, ScrollY: 1,
+		Color:   rl.White,
+		target:  rl.LoadRenderTexture(int32(w), int32(h)),
+		width:   w,
+		height:  h,
+		pixels:  make([]color.RGBA, w*h),
+		scratch: make([]color.RGBA, w*h),
+		visible: true,
+	}
+	rl.SetTextureFilter(c.target.Texture, rl.FilterPoint)
+	c.Clear(rl.Blank)
+	return c
+}
+
+// Width returns the canvas width in pixels.
+func (c *Canvas) Width() int { return c.width }
+
+// Height returns the canvas height in pixels.
+func (c *Canvas) Height() int { return c.height }
+
+// Texture returns the GPU texture, with any pending CPU edits uploaded.
+// Note that render textures are stored upside down.
+func (c *Canvas) Texture() rl.Texture2D {
+	c.upload()
+	return c.target.Texture
+}
+
+// Unload frees the render texture.
+func (c *Canvas) Unload() {
+	rl.UnloadRenderTexture(c.target)
+	c.pixels, c.scratch = nil, nil
+}
+
+// -----------------------------------------------------------------------------
+// GPU drawing
+// -----------------------------------------------------------------------------
+
+// Begin opens a batch so several draw calls share one texture-mode pass.
+// Without it every draw call opens and closes its own pass.
+func (c *Canvas) Begin() {
+	if c.batching {
+		return
+	}
+	c.upload()
+	c.resume = suspendBatch()
+	rl.BeginTextureMode(c.target)
+	c.batching = true
+}
+
+// End closes a batch opened by Begin.
+func (c *Canvas) End() {
+	if !c.batching {
+		return
+	}
+	rl.EndTextureMode()
+	c.resume()
+	c.batching = false
+	c.cpuStale = true
+}
+
+// draw runs fn inside texture mode, reusing an open batch.
+func (c *Canvas) draw(fn func()) {
+	if c.batching {
+		fn()
+		return
+	}
+	c.Begin()
+	fn()
+	c.End()
+}
+
+// Clear fills the whole canvas with col.  Unlike the draw calls it
+// replaces pixels rather than blending, so rl.Blank erases.
+func (c *Canvas) Clear(col rl.Color) {
+	c.draw(func() { rl.ClearBackground(col) })
+}
+
+// DrawLine draws a 1px line.
+func (c *Canvas) DrawLine(x1, y1, x2, y2 float32, col rl.Color) {
+	c.draw(func() { rl.DrawLineV(rl.NewVector2(x1, y1), rl.NewVector2(x2, y2), col) })
+}
+
+// DrawLineThick draws a line of the given thickness.
+func (c *Canvas) DrawLineThick(x1, y1, x2, y2, thick float32, col rl.Color) {
+	c.draw(func() { rl.DrawLineEx(rl.NewVector2(x1, y1), rl.NewVector2(x2, y2), thick, col) })
+}
+
+// DrawRect fills a rectangle.
+func (c *Canvas) DrawRect(x, y, w, h float32, col rl.Color) {
+	c.draw(func() { rl.DrawRectangleRec(rl.NewRectangle(x, y, w, h), col) })
+}
+
+// DrawRectLines outlines a rectangle.
+func (c *Canvas) DrawRectLines(x, y, w, h float32, col rl.Color) {
+	c.draw(func() { rl.DrawRectangleLinesEx(rl.NewRectangle(x, y, w, h), 1, col) })
+}
+
+// DrawCircle fills a circle.
+func (c *Canvas) DrawCircle(x, y, radius float32, col rl.Color) {
+	c.draw(func() { rl.DrawCircleV(rl.NewVector2(x, y), radius, col) })
+}
+
+// DrawCircleLines outlines a circle.
+func (c *Canvas) DrawCircleLines(x, y, radius float32, col rl.Color) {
+	c.draw(func() { rl.DrawCircleLinesV(rl.NewVector2(x, y), radius, col) })
+}
+
+// DrawTexture copies region src of tex to (x,y).
+func (c *Canvas) DrawTexture(tex rl.Texture2D, src rl.Rectangle, x, y float32, col rl.Color) {
+	c.draw(func() { rl.DrawTextureRec(tex, src, rl.NewVector2(x, y), col) })
+}
+
+// DrawText writes s at (x,y) in the default font.
+func (c *Canvas) DrawText(s string, x, y, size float32, col rl.Color) {
+	c.DrawTextFont("", s, x, y, size, col)
+}
+
+// DrawTextFont writes s at (x,y) in the registered font `font`.
+func (c *Canvas) DrawTextFont(font, s string, x, y, size float32, col rl.Color) {
+	fnt, size := FontFor(font, size)
+	c.draw(func() { rl.DrawTextEx(fnt, s, rl.NewVector2(x, y), size, 1, col) })
+}
+
+// DrawGraphic renders g into the canvas with (x,y) as its position, as if
+// the camera sat at the canvas origin.  Graphics without SetPosition are
+// drawn where they are.
+func (c *Canvas) DrawGraphic(g Graphic, x, y float32) {
+	if p, ok := g.(Positioner); ok {
+		p.SetPosition(x, y)
+	}
+	c.draw(func() { g.Render(0, 0) })
+}
+
+// -----------------------------------------------------------------------------
+// CPU mirror
+// -----------------------------------------------------------------------------
+
+// GetPixel returns the pixel at (x,y), or rl.Blank outside the canvas.
+func (c *Canvas) GetPixel(x, y int) rl.Color {
+	if x < 0 || y < 0 || x >= c.width || y >= c.height {
+		return rl.Blank
+	}
+	c.download()
+	return c.pixels[y*c.width+x]
+}
+
+// SetPixel writes one pixel without blending.  Cheap to call many times;
+// the GPU copy is refreshed once before the next draw or Render.
+func (c *Canvas) SetPixel(x, y int, col rl.Color) {
+	if x < 0 || y < 0 || x >= c.width || y >= c.height {
+		return
+	}
+	c.download()
+	c.pixels[y*c.width+x] = col
+	c.gpuStale = true
+}
+
+// FloodFill replaces the 4-connected area of pixels matching the colour at
+// (x,y) with col.  Returns the number of pixels changed.
+func (c *Canvas) FloodFill(x, y int, col rl.Color) int {
+	if x < 0 || y < 0 || x >= c.width || y >= c.height {
+		return 0
+	}
+	c.download()
+	target := c.pixels[y*c.width+x]
+	if target == col {
+		return 0
+	}
+
+	// scanline fill: each stack entry seeds one horizontal run
+	n := 0
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		px, py := p[0], p[1]
+		row := c.pixels[py*c.width : (py+1)*c.width]
+		if row[px] != target {
+			continue
+		}
+		l, r := px, px
+		for l > 0 && row[l-1] == target {
+			l--
+		}
+		for r < c.width-1 && row[r+1] == target {
+			r++
+		}
+		for i := l; i <= r; i++ {
+			row[i] = col
+			n++
+		}
+		for _, ny := range [2]int{py - 1, py + 1} {
+			if ny < 0 || ny >= c.height {
+				continue
+			}
+			next := c.pixels[ny*c.width : (ny+1)*c.width]
+			for i := l; i <= r; i++ {
+				if next[i] == target && (i == l || next[i-1] != target) {
+					stack = append(stack, [2]int{i, ny})
+				}
+			}
+		}
+	}
+	c.gpuStale = true
+	return n
+}
+
+// Pixels returns the CPU mirror, row-major with row 0 at the top.  Call
+// MarkDirty after modifying it directly.
+func (c *Canvas) Pixels() []rl.Color {
+	c.download()
+	return c.pixels
+}
+
+// MarkDirty schedules the CPU mirror for upload after direct edits.
+func (c *Canvas) MarkDirty() { c.gpuStale = true }
+
+// Image returns a copy of the canvas as a Go image.
+func (c *Canvas) Image() *image.RGBA {
+	c.download()
+	img := image.NewRGBA(image.Rect(0, 0, c.width, c.height))
+	for i, p := range c.pixels {
+		img.Pix[i*4+0] = p.R
+		img.Pix[i*4+1] = p.G
+		img.Pix[i*4+2] = p.B
+		img.Pix[i*4+3] = p.A
+	}
+	return img
+}
+
+// SavePNG writes the canvas to a PNG file.
+func (c *Canvas) SavePNG(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, c.Image()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// download refreshes the mirror from the GPU when draws made it stale.
+func (c *Canvas) download() {
+	if !c.cpuStale {
+		return
+	}
+	img := rl.LoadImageFromTexture(c.target.Texture)
+	cols := rl.LoadImageColors(img)
+	for y := 0; y < c.height; y++ {
+		src := cols[(c.height-1-y)*c.width : (c.height-y)*c.width]
+		copy(c.pixels[y*c.width:(y+1)*c.width], src)
+	}
+	rl.UnloadImageColors(cols)
+	rl.UnloadImage(img)
+	c.cpuStale = false
+}
+
+// upload pushes CPU edits to the GPU, flipping rows to texture order.
+func (c *Canvas) upload() {
+	if !c.gpuStale {
+		return
+	}
+	for y := 0; y < c.height; y++ {
+		copy(c.scratch[(c.height-1-y)*c.width:(c.height-y)*c.width], c.pixels[y*c.width:(y+1)*c.width])
+	}
+	rl.UpdateTexture(c.target.Texture, c.scratch)
+	c.gpuStale = false
+}
+
+// -----------------------------------------------------------------------------
+// Graphic
+// -----------------------------------------------------------------------------
+
+// Update is a no-op for Canvases.
+func (c *Canvas) Update(dt float64) {}
+
+// IsVisible reports current visibility.
+func (c *Canvas) IsVisible() bool { return c.visible }
+
+// SetVisible toggles drawing.
+func (c *Canvas) SetVisible(v bool) { c.visible = v }
+
+// SetPosition moves the top-left corner to (x,y).
+func (c *Canvas) SetPosition(x, y float32) { c.X, c.Y = x, y }
+
+// Bounds implements Bounder.
+func (c *Canvas) Bounds(camX, camY float32) (rl.Rectangle, bool) {
+	w, h := float32(c.width)*c.Scale, float32(c.height)*c.Scale
+	return rl.NewRectangle(c.X-camX*c.ScrollX, c.Y-camY*c.ScrollY, w, h), true
+}
+
+// Render draws the canvas with its top-left corner at (X,Y).
+func (c *Canvas) Render(camX, camY float32) {
+	if !c.visible {
+		return
+	}
+	c.upload()
+	w, h := float32(c.width), float32(c.height)
+	src := rl.NewRectangle(0, 0, w, -h) // render textures are upside down
+	dst := rl.NewRectangle(c.X-camX*c.ScrollX, c.Y-camY*c.ScrollY, w*c.Scale, h*c.Scale)
+	DrawSprite(Sprite{Texture: c.target.Texture, Src: src, Dst: dst, Color: c.Color})
+}
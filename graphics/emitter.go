@@ -0,0 +1,337 @@
+// runt/graphics/emitter.go
+package graphics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/ease"
+)
+
+// ParticleType describes how one kind of particle looks and moves.  Create
+// it with Emitter.DefineType and configure it with the Set* methods, which
+// return the type so calls can be chained.
+type ParticleType struct {
+	Name string
+
+	// Frames are source rectangles in the emitter texture, played once over
+	// each particle's lifetime.
+	Frames []rl.Rectangle
+
+	// Lifetime range in seconds (at least minParticleLife)
+	LifeMin, LifeMax float32
+
+	// Launch direction in degrees (0 = right, 90 = down) ± AngleSpread/2
+	Angle, AngleSpread float32
+	// Launch speed range in pixels per second
+	SpeedMin, SpeedMax float32
+
+	// Constant acceleration in pixels per second²
+	GravityX, GravityY float32
+
+	// Curves over the lifetime; a nil ease is linear
+	AlphaStart, AlphaEnd float32
+	AlphaEase            ease.Func
+	ScaleStart, ScaleEnd float32
+	ScaleEase            ease.Func
+	ColorStart, ColorEnd rl.Color
+	ColorEase            ease.Func
+
+	// Spawn area around the emit point (0 = a single point)
+	AreaWidth, AreaHeight float32
+
+	// continuous emission: particles per second and the fractional carry
+	rate, carry float32
+	index       uint16
+}
+
+// minParticleLife keeps age/life finite for zero or negative lifetimes;
+// such particles show their first frame once and die on the next Update.
+const minParticleLife = 1e-3
+
+// SetMotion sets launch angle, spread, speed range and lifetime range.
+func (pt *ParticleType) SetMotion(angle, spread, speedMin, speedMax, lifeMin, lifeMax float32) *ParticleType {
+	pt.Angle, pt.AngleSpread = angle, spread
+	pt.SpeedMin, pt.SpeedMax = speedMin, speedMax
+	pt.LifeMin, pt.LifeMax = lifeMin, lifeMax
+	return pt
+}
+
+// SetGravity sets the constant acceleration.
+func (pt *ParticleType) SetGravity(gx, gy float32) *ParticleType {
+	pt.GravityX, pt.GravityY = gx, gy
+	return pt
+}
+
+// SetAlpha fades from start to end over the lifetime.
+func (pt *ParticleType) SetAlpha(start, end float32, e ease.Func) *ParticleType {
+	pt.AlphaStart, pt.AlphaEnd, pt.AlphaEase = start, end, e
+	return pt
+}
+
+// SetScale scales from start to end over the lifetime.
+func (pt *ParticleType) SetScale(start, end float32, e ease.Func) *ParticleType {
+	pt.ScaleStart, pt.ScaleEnd, pt.ScaleEase = start, end, e
+	return pt
+}
+
+// SetColor blends from start to end over the lifetime with ColorLerp.
+func (pt *ParticleType) SetColor(start, end rl.Color, e ease.Func) *ParticleType {
+	pt.ColorStart, pt.ColorEnd, pt.ColorEase = start, end, e
+	return pt
+}
+
+// SetArea spawns particles anywhere in a w×h box centred on the emit point.
+func (pt *ParticleType) SetArea(w, h float32) *ParticleType {
+	pt.AreaWidth, pt.AreaHeight = w, h
+	return pt
+}
+
+// EmitterStats are per-emitter counters for debug overlays.
+type EmitterStats struct {
+	Alive    int // particles currently alive
+	Capacity int // size of the preallocated buffer
+	Emitted  int // particles spawned since the last ResetStats
+	Dropped  int // spawns refused because the buffer was full
+}
+
+// Emitter simulates and draws particles from a fixed-size, preallocated
+// struct-of-arrays buffer.  Emitting, updating and rendering never allocate,
+// so tens of thousands of particles cost no garbage.
+type Emitter struct {
+	Texture rl.Texture2D
+
+	// World position used by Stream and as the origin of Emit offsets
+	X, Y float32
+
+	// Parallax scrolling factors (1 == follow camera exactly)
+	ScrollX, ScrollY float32
+
+	types  []*ParticleType
+	byName map[string]*ParticleType
+
+	// particle buffer (struct of arrays); the first n entries are alive
+	n                int
+	px, py, vx, vy   []float32
+	age, life        []float32
+	kind             []uint16
+	emitted, dropped int
+	rng              uint64
+	visible          bool
+}
+
+// NewEmitter creates an emitter drawing from tex with room for capacity
+// particles.
+func NewEmitter(tex rl.Texture2D, capacity int) *Emitter {
+	rl.SetTextureFilter(tex, rl.FilterPoint)
+	return &Emitter{
+		Texture: tex,
+		ScrollX: 1, ScrollY: 1,
+		byName:  make(map[string]*ParticleType),
+		px:      make([]float32, capacity),
+		py:      make([]float32, capacity),
+		vx:      make([]float32, capacity),
+		vy:      make([]float32, capacity),
+		age:     make([]float32, capacity),
+		life:    make([]float32, capacity),
+		kind:    make([]uint16, capacity),
+		rng:     0x9E3779B97F4A7C15,
+		visible: true,
+	}
+}
+
+// DefineType registers a particle type using the given texture frames.
+// With no frames the whole texture is used.
+func (em *Emitter) DefineType(name string, frames ...rl.Rectangle) *ParticleType {
+	if len(frames) == 0 {
+		frames = []rl.Rectangle{rl.NewRectangle(0, 0, float32(em.Texture.Width), float32(em.Texture.Height))}
+	}
+	pt := &ParticleType{
+		Name:       name,
+		Frames:     frames,
+		LifeMin:    1,
+		LifeMax:    1,
+		AlphaStart: 1, AlphaEnd: 1,
+		ScaleStart: 1, ScaleEnd: 1,
+		ColorStart: rl.White, ColorEnd: rl.White,
+		index: uint16(len(em.types)),
+	}
+	em.types = append(em.types, pt)
+	em.byName[name] = pt
+	return pt
+}
+
+// DefineGridType registers a type whose frames are cells of a frameW×frameH
+// grid laid over the texture, numbered left→right, top→bottom.
+func (em *Emitter) DefineGridType(name string, frameW, frameH int, cells ...int) *ParticleType {
+	cols := int(em.Texture.Width) / frameW
+	if cols < 1 {
+		cols = 1
+	}
+	frames := make([]rl.Rectangle, len(cells))
+	for i, c := range cells {
+		frames[i] = rl.NewRectangle(float32(c%cols*frameW), float32(c/cols*frameH), float32(frameW), float32(frameH))
+	}
+	return em.DefineType(name, frames...)
+}
+
+// Type returns the named particle type, or nil.
+func (em *Emitter) Type(name string) *ParticleType { return em.byName[name] }
+
+// Emit spawns one particle of the named type at (X+x, Y+y).
+func (em *Emitter) Emit(name string, x, y float32) {
+	if pt := em.byName[name]; pt != nil {
+		em.spawn(pt, em.X+x, em.Y+y)
+	}
+}
+
+// Burst spawns count particles of the named type at (X+x, Y+y).
+func (em *Emitter) Burst(name string, x, y float32, count int) {
+	pt := em.byName[name]
+	if pt == nil {
+		return
+	}
+	for i := 0; i < count; i++ {
+		em.spawn(pt, em.X+x, em.Y+y)
+	}
+}
+
+// Stream emits the named type continuously from (X,Y) at rate particles
+// per second.  A rate of 0 stops the stream.
+func (em *Emitter) Stream(name string, rate float32) {
+	if pt := em.byName[name]; pt != nil {
+		pt.rate = rate
+		if rate == 0 {
+			pt.carry = 0
+		}
+	}
+}
+
+// Clear kills every particle.
+func (em *Emitter) Clear() { em.n = 0 }
+
+// Count returns the number of live particles.
+func (em *Emitter) Count() int { return em.n }
+
+// Capacity returns the size of the particle buffer.
+func (em *Emitter) Capacity() int { return len(em.px) }
+
+// Stats returns counters for debug overlays.
+func (em *Emitter) Stats() EmitterStats {
+	return EmitterStats{
+		Alive:    em.n,
+		Capacity: len(em.px),
+		Emitted:  em.emitted,
+		Dropped:  em.dropped,
+	}
+}
+
+// ResetStats zeroes the Emitted and Dropped counters.
+func (em *Emitter) ResetStats() { em.emitted, em.dropped = 0, 0 }
+
+// spawn initialises the next free slot; it drops the particle when full.
+func (em *Emitter) spawn(pt *ParticleType, x, y float32) {
+	if em.n == len(em.px) {
+		em.dropped++
+		return
+	}
+	i := em.n
+	em.n++
+	em.emitted++
+
+	angle := (pt.Angle + (em.rand()-0.5)*pt.AngleSpread) * math.Pi / 180
+	speed := pt.SpeedMin + em.rand()*(pt.SpeedMax-pt.SpeedMin)
+	sin, cos := math.Sincos(float64(angle))
+
+	em.px[i] = x + (em.rand()-0.5)*pt.AreaWidth
+	em.py[i] = y + (em.rand()-0.5)*pt.AreaHeight
+	em.vx[i] = float32(cos) * speed
+	em.vy[i] = float32(sin) * speed
+	em.age[i] = 0
+	em.life[i] = max(pt.LifeMin+em.rand()*(pt.LifeMax-pt.LifeMin), minParticleLife)
+	em.kind[i] = pt.index
+}
+
+// Update runs continuous streams, integrates motion and removes dead
+// particles by swapping the last live particle into their slot.
+func (em *Emitter) Update(dt float64) {
+	fdt := float32(dt)
+
+	for _, pt := range em.types {
+		if pt.rate <= 0 {
+			continue
+		}
+		pt.carry += pt.rate * fdt
+		for ; pt.carry >= 1; pt.carry-- {
+			em.spawn(pt, em.X, em.Y)
+		}
+	}
+
+	for i := 0; i < em.n; {
+		em.age[i] += fdt
+		if em.age[i] >= em.life[i] {
+			em.n--
+			em.px[i], em.py[i] = em.px[em.n], em.py[em.n]
+			em.vx[i], em.vy[i] = em.vx[em.n], em.vy[em.n]
+			em.age[i], em.life[i] = em.age[em.n], em.life[em.n]
+			em.kind[i] = em.kind[em.n]
+			continue // re-check the particle swapped in
+		}
+		pt := em.types[em.kind[i]]
+		em.vx[i] += pt.GravityX * fdt
+		em.vy[i] += pt.GravityY * fdt
+		em.px[i] += em.vx[i] * fdt
+		em.py[i] += em.vy[i] * fdt
+		i++
+	}
+}
+
+// IsVisible reports current visibility.
+func (em *Emitter) IsVisible() bool { return em.visible }
+
+// SetVisible toggles drawing.
+func (em *Emitter) SetVisible(v bool) { em.visible = v }
+
+// SetPosition moves the emit origin.  Live particles stay where they are.
+func (em *Emitter) SetPosition(x, y float32) { em.X, em.Y = x, y }
+
+// Render draws every live particle centred on its position.
+func (em *Emitter) Render(camX, camY float32) {
+	if !em.visible {
+		return
+	}
+	ox, oy := camX*em.ScrollX, camY*em.ScrollY
+	for i := 0; i < em.n; i++ {
+		pt := em.types[em.kind[i]]
+		t := em.age[i] / em.life[i]
+		et := float64(t)
+
+		frame := pt.Frames[int(t*float32(len(pt.Frames)))%len(pt.Frames)]
+		scale := pt.ScaleStart + (pt.ScaleEnd-pt.ScaleStart)*float32(pt.ScaleEase.Apply(et))
+		alpha := pt.AlphaStart + (pt.AlphaEnd-pt.AlphaStart)*float32(pt.AlphaEase.Apply(et))
+		col := ColorLerp(pt.ColorStart, pt.ColorEnd, float32(pt.ColorEase.Apply(et)))
+		col.A = uint8(float32(col.A) * clamp01(alpha))
+
+		w, h := frame.Width*scale, frame.Height*scale
+		dst := rl.NewRectangle(em.px[i]-ox, em.py[i]-oy, w, h)
+		DrawSprite(Sprite{Texture: em.Texture, Src: frame, Dst: dst, Origin: rl.NewVector2(w/2, h/2), Color: col})
+	}
+}
+
+// rand returns a float32 in [0,1) from the emitter's xorshift state.
+func (em *Emitter) rand() float32 {
+	em.rng ^= em.rng << 13
+	em.rng ^= em.rng >> 7
+	em.rng ^= em.rng << 17
+	return float32(em.rng>>40) / (1 << 24)
+}
+
+func clamp01(v float32) float32 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,104 @@
+// runt/graphics/fonts.go
+package graphics
+
+import (
+	"sync"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// DefaultFontPath is the TTF used when no font is named or a name is not
+// registered.  The loader falls back to the embedded copy of VT323.
+const DefaultFontPath = "VT323-Regular.ttf"
+
+// fontEntry is one registered font.  Scalable fonts are rasterised lazily
+// at each size asked for; bitmap fonts have a single native size.
+type fontEntry struct {
+	path   string
+	runes  []rune
+	bitmap *rl.Font
+}
+
+var (
+	fontMu   sync.Mutex
+	fontRegs = make(map[string]fontEntry)
+)
+
+// RegisterFont registers a TTF/OTF under `name`.  It is rasterised at
+// whatever size a Text asks for, with glyphs for `runes` (nil = ASCII; see
+// loader.Latin1, loader.Cyrillic, loader.Runes).  Registering "" replaces
+// the default font.
+func RegisterFont(name, path string, runes []rune) {
+	fontMu.Lock()
+	defer fontMu.Unlock()
+	fontRegs[name] = fontEntry{path: path, runes: runes}
+}
+
+// RegisterBMFont registers an AngelCode BMFont (.fnt + page image).
+func RegisterBMFont(name, path string) {
+	f := loader.LoadBMFont(path)
+	registerBitmap(name, f)
+}
+
+// RegisterGridFont registers a monospaced image font cut into cellW×cellH
+// cells, where cell i draws the i-th rune of `chars`.
+func RegisterGridFont(name, path string, cellW, cellH int, chars string) {
+	f := loader.LoadGridFont(path, cellW, cellH, chars)
+	registerBitmap(name, f)
+}
+
+// RegisterRaylibFont registers an already loaded rl.Font as a bitmap font.
+func RegisterRaylibFont(name string, f rl.Font) {
+	registerBitmap(name, f)
+}
+
+func registerBitmap(name string, f rl.Font) {
+	fontMu.Lock()
+	defer fontMu.Unlock()
+	fontRegs[name] = fontEntry{bitmap: &f}
+}
+
+// UnregisterFont forgets `name`; Texts created afterwards fall back to the
+// default font.
+func UnregisterFont(name string) {
+	fontMu.Lock()
+	defer fontMu.Unlock()
+	delete(fontRegs, name)
+}
+
+// HasFont reports whether `name` is registered.
+func HasFont(name string) bool {
+	fontMu.Lock()
+	defer fontMu.Unlock()
+	_, ok := fontRegs[name]
+	return ok
+}
+
+// FontFor returns the font registered as `name` for drawing at `size`,
+// falling back to the default font.  A size of 0 picks a bitmap font's
+// native size (or 16 for scalable fonts).  The second result is the size
+// to draw at.
+func FontFor(name string, size float32) (rl.Font, float32) {
+	fontMu.Lock()
+	e, ok := fontRegs[name]
+	if !ok {
+		e, ok = fontRegs[""]
+	}
+	fontMu.Unlock()
+
+	if ok && e.bitmap != nil {
+		if size <= 0 {
+			size = float32(e.bitmap.BaseSize)
+		}
+		return *e.bitmap, size
+	}
+	if size <= 0 {
+		size = 16
+	}
+	path := DefaultFontPath
+	if ok {
+		path = e.path
+	}
+	return loader.LoadFontRunes(path, int32(size), e.runes), size
+}
@@ -0,0 +1,173 @@
+// runt/graphics/graphiclist.go
+package graphics
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// Graphiclist is a composite Graphic: it holds child graphics with offsets
+// relative to its own position and updates/renders them in order, so an
+// entity can carry a body, a shadow and a name tag as one Graphic.
+type Graphiclist struct {
+	// World position the child offsets are relative to
+	X, Y float32
+
+	children []listChild
+	visible  bool
+}
+
+// listChild is one child with its relative offset.
+type listChild struct {
+	graphic Graphic
+	ox, oy  float32
+}
+
+// NewGraphiclist creates a list holding gs at offset (0,0), drawn in order.
+func NewGraphiclist(gs ...Graphic) *Graphiclist {
+	gl := &Graphiclist{visible: true}
+	for _, g := range gs {
+		gl.Add(g)
+	}
+	return gl
+}
+
+// Add appends g on top of the existing children at offset (0,0).
+func (gl *Graphiclist) Add(g Graphic) {
+	gl.AddAt(g, 0, 0)
+}
+
+// AddAt appends g on top of the existing children at offset (ox,oy).
+func (gl *Graphiclist) AddAt(g Graphic, ox, oy float32) {
+	gl.children = append(gl.children, listChild{g, ox, oy})
+}
+
+// Remove drops g from the list.  Returns true if it was present.
+func (gl *Graphiclist) Remove(g Graphic) bool {
+	i := gl.index(g)
+	if i < 0 {
+		return false
+	}
+	gl.children = append(gl.children[:i], gl.children[i+1:]...)
+	return true
+}
+
+// RemoveAll empties the list.
+func (gl *Graphiclist) RemoveAll() {
+	gl.children = gl.children[:0]
+}
+
+// Len returns the number of children.
+func (gl *Graphiclist) Len() int { return len(gl.children) }
+
+// Children returns the children in draw order.
+func (gl *Graphiclist) Children() []Graphic {
+	out := make([]Graphic, len(gl.children))
+	for i, c := range gl.children {
+		out[i] = c.graphic
+	}
+	return out
+}
+
+// SetOffset moves g relative to the list's position.
+func (gl *Graphiclist) SetOffset(g Graphic, ox, oy float32) {
+	if i := gl.index(g); i >= 0 {
+		gl.children[i].ox, gl.children[i].oy = ox, oy
+	}
+}
+
+// Offset returns g's offset relative to the list's position.
+func (gl *Graphiclist) Offset(g Graphic) (float32, float32) {
+	if i := gl.index(g); i >= 0 {
+		return gl.children[i].ox, gl.children[i].oy
+	}
+	return 0, 0
+}
+
+// MoveTo places g at draw index i (0 is drawn first), shifting the others.
+func (gl *Graphiclist) MoveTo(g Graphic, i int) {
+	from := gl.index(g)
+	if from < 0 {
+		return
+	}
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(gl.children) {
+		i = len(gl.children) - 1
+	}
+	c := gl.children[from]
+	gl.children = append(gl.children[:from], gl.children[from+1:]...)
+	gl.children = append(gl.children[:i], append([]listChild{c}, gl.children[i:]...)...)
+}
+
+// BringToFront draws g after every other child.
+func (gl *Graphiclist) BringToFront(g Graphic) { gl.MoveTo(g, len(gl.children)-1) }
+
+// SendToBack draws g before every other child.
+func (gl *Graphiclist) SendToBack(g Graphic) { gl.MoveTo(g, 0) }
+
+// Update updates every child in order.
+func (gl *Graphiclist) Update(dt float64) {
+	for _, c := range gl.children {
+		c.graphic.Update(dt)
+	}
+}
+
+// IsVisible reports current visibility.
+func (gl *Graphiclist) IsVisible() bool { return gl.visible }
+
+// SetVisible toggles drawing of the whole list.
+func (gl *Graphiclist) SetVisible(v bool) { gl.visible = v }
+
+// SetPosition moves the list; children follow on the next Render.
+func (gl *Graphiclist) SetPosition(x, y float32) { gl.X, gl.Y = x, y }
+
+// Bounds implements Bounder with the union of the visible children.  It
+// is unknown if any visible child can't report its own.
+func (gl *Graphiclist) Bounds(camX, camY float32) (rl.Rectangle, bool) {
+	var u rl.Rectangle
+	found := false
+	for _, c := range gl.children {
+		if !c.graphic.IsVisible() {
+			continue
+		}
+		b, ok := c.graphic.(Bounder)
+		if !ok {
+			return rl.Rectangle{}, false
+		}
+		if p, ok := c.graphic.(Positioner); ok {
+			p.SetPosition(gl.X+c.ox, gl.Y+c.oy)
+		}
+		r, ok := b.Bounds(camX, camY)
+		if !ok {
+			return rl.Rectangle{}, false
+		}
+		u = unionRect(u, r, found)
+		found = true
+	}
+	return u, found
+}
+
+// Render positions each visible child at the list position plus its offset
+// and draws it.
+func (gl *Graphiclist) Render(camX, camY float32) {
+	if !gl.visible {
+		return
+	}
+	for _, c := range gl.children {
+		if !c.graphic.IsVisible() {
+			continue
+		}
+		if p, ok := c.graphic.(Positioner); ok {
+			p.SetPosition(gl.X+c.ox, gl.Y+c.oy)
+		}
+		c.graphic.Render(camX, camY)
+	}
+}
+
+func (gl *Graphiclist) index(g Graphic) int {
+	for i, c := range gl.children {
+		if c.graphic == g {
+			return i
+		}
+	}
+	return -1
+}
@@ -1,5 +1,7 @@
 package graphics
 
+import rl "github.com/gen2brain/raylib-go/raylib"
+
 // Graphic is the minimal interface that all renderable things must satisfy.
 type Graphic interface {
 	// Update is called once per‐frame before Render.
@@ -10,3 +12,78 @@ type Graphic interface {
 	SetVisible(bool)
 	IsVisible() bool
 }
+
+// Positioner is implemented by graphics whose world position is driven by
+// their owner.  BaseEntity.Render and Graphiclist push positions through it.
+type Positioner interface {
+	SetPosition(x, y float32)
+}
+
+// Bounder is implemented by graphics that know the screen area they draw
+// to, before zoom/rotation, for the given camera offset (parallax included).
+// ok is false when the area is unknown.  World culling relies on it.
+type Bounder interface {
+	Bounds(camX, camY float32) (r rl.Rectangle, ok bool)
+}
+
+// ViewWidth and ViewHeight are the size of the visible area in world units.
+// runt.Resize keeps them in sync with the screen; graphics that cull or tile
+// against the view (Tilemap, Backdrop) use them when no view rectangle is
+// set.
+var ViewWidth, ViewHeight float32
+
+// viewRect is the area being drawn, set per camera by SetViewRect.
+var viewRect rl.Rectangle
+
+// SetViewRect sets the area the current camera shows, in the screen space
+// Render draws to before zoom/rotation; an empty rectangle goes back to
+// (0,0,ViewWidth,ViewHeight).  The Engine and World.Render set it per
+// camera and view, so zoomed-out cameras still get their edges drawn.
+func SetViewRect(r rl.Rectangle) { viewRect = r }
+
+// ViewRect returns the area graphics cull and tile against.
+func ViewRect() rl.Rectangle {
+	if viewRect.Width > 0 && viewRect.Height > 0 {
+		return viewRect
+	}
+	if ViewWidth > 0 && ViewHeight > 0 {
+		return rl.NewRectangle(0, 0, ViewWidth, ViewHeight)
+	}
+	return rl.NewRectangle(0, 0, float32(rl.GetScreenWidth()), float32(rl.GetScreenHeight()))
+}
+
+// unionRect returns the rectangle covering a and b; with haveA false it is
+// just b.
+func unionRect(a, b rl.Rectangle, haveA bool) rl.Rectangle {
+	if !haveA {
+		return b
+	}
+	x0, y0 := min(a.X, b.X), min(a.Y, b.Y)
+	x1 := max(a.X+a.Width, b.X+b.Width)
+	y1 := max(a.Y+a.Height, b.Y+b.Height)
+	return rl.NewRectangle(x0, y0, x1-x0, y1-y0)
+}
+
+// abs32 is math.Abs for float32.
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// ColorLerp blends two colors by t in [0,1].  It is pure Go (no cgo call),
+// so it is cheap enough for per-particle use; runt.ColorLerp wraps it.
+func ColorLerp(c1, c2 rl.Color, t float32) rl.Color {
+	if t <= 0 {
+		return c1
+	}
+	if t >= 1 {
+		return c2
+	}
+	r := uint8(float32(c1.R) + (float32(c2.R)-float32(c1.R))*t)
+	g := uint8(float32(c1.G) + (float32(c2.G)-float32(c1.G))*t)
+	b := uint8(float32(c1.B) + (float32(c2.B)-float32(c1.B))*t)
+	a := uint8(float32(c1.A) + (float32(c2.A)-float32(c1.A))*t)
+	return rl.NewColor(r, g, b, a)
+}
@@ -1,6 +1,8 @@
 package graphics
 
 import (
+	"math"
+
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
@@ -29,6 +31,12 @@ type Image struct {
 	// Tint color & alpha override
 	Color rl.Color
 
+	// Optional fragment/vertex shader applied while drawing
+	Shader *Shader
+
+	// Blend mode (rl.BlendAlpha, the zero value, is the default)
+	Blend rl.BlendMode
+
 	// Visibility flag
 	visible bool
 }
@@ -86,6 +94,9 @@ func (img *Image) IsVisible() bool { return img.visible }
 // SetVisible toggles drawing.
 func (img *Image) SetVisible(v bool) { img.visible = v }
 
+// SetPosition moves the Image's pivot to (x,y) in world space.
+func (img *Image) SetPosition(x, y float32) { img.X, img.Y = x, y }
+
 // Render draws the Image at its world position, rotating & scaling around
 // the center of the sprite.  camX,camY are the camera offsets.
 func (img *Image) Render(camX, camY float32) {
@@ -105,15 +116,33 @@ func (img *Image) Render(camX, camY float32) {
 	// pivot inside that quad is its center
 	origin := rl.NewVector2(w/2, h/2)
 
-	// full-precision draw with rotation & scale
-	rl.DrawTexturePro(
-		img.Texture,
-		img.SrcRec,
-		dst,
-		origin,
-		img.Rotation,
-		img.Color,
-	)
+	// full-precision draw with rotation & scale, batched when a Batch is
+	// active
+	DrawSprite(Sprite{
+		Texture:  img.Texture,
+		Shader:   img.Shader,
+		Blend:    img.Blend,
+		Src:      img.SrcRec,
+		Dst:      dst,
+		Origin:   origin,
+		Rotation: img.Rotation,
+		Color:    img.Color,
+	})
+}
+
+// Bounds implements Bounder.  A rotated image reports the square its
+// corners sweep.
+func (img *Image) Bounds(camX, camY float32) (rl.Rectangle, bool) {
+	w := img.SrcRec.Width * img.ScaleX * img.Scale
+	h := img.SrcRec.Height * img.ScaleY * img.Scale
+	cx := img.X - camX*img.ScrollX
+	cy := img.Y - camY*img.ScrollY
+	if img.Rotation != 0 {
+		r := float32(math.Hypot(float64(w), float64(h))) / 2
+		return rl.NewRectangle(cx-r, cy-r, 2*r, 2*r), true
+	}
+	w, h = abs32(w), abs32(h)
+	return rl.NewRectangle(cx-w/2, cy-h/2, w, h), true
 }
 
 // NewCircle creates a filled circle Image (transparent outside).
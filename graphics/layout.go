@@ -0,0 +1,239 @@
+// runt/graphics/layout.go
+package graphics
+
+import rl "github.com/gen2brain/raylib-go/raylib"
+
+// TextLayout is the wrapped, aligned placement of a Text's content.  It is
+// computed once and reused until the content, font, size, spacing, wrap or
+// alignment changes.  All coordinates are relative to the Text position.
+type TextLayout struct {
+	Glyphs []Glyph
+	Lines  []LineBox
+
+	// Wrapped bounds: widest line and total height
+	Width, Height float32
+}
+
+// Glyph is one laid-out character or inline icon.
+type Glyph struct {
+	Rune rune // 0 for icons
+
+	// Box relative to the Text position
+	X, Y, Width, Height float32
+
+	// Line is the index into TextLayout.Lines
+	Line int
+	// Index is the rune offset in the content with markup stripped (icons
+	// count as one rune)
+	Index int
+
+	icon  *icon
+	style style
+}
+
+// LineBox is one laid-out line.
+type LineBox struct {
+	X, Y, Width, Height float32
+
+	// Glyphs[First:Last] are on this line
+	First, Last int
+}
+
+// Rect returns the glyph box.
+func (g Glyph) Rect() rl.Rectangle { return rl.NewRectangle(g.X, g.Y, g.Width, g.Height) }
+
+// Bounds returns the wrapped bounds, including alignment offset.
+func (l *TextLayout) Bounds() rl.Rectangle {
+	if len(l.Lines) == 0 {
+		return rl.Rectangle{}
+	}
+	x0, x1 := l.Lines[0].X, l.Lines[0].X+l.Lines[0].Width
+	for _, ln := range l.Lines[1:] {
+		x0 = min(x0, ln.X)
+		x1 = max(x1, ln.X+ln.Width)
+	}
+	return rl.NewRectangle(x0, 0, x1-x0, l.Height)
+}
+
+// LineAt returns the line under y, clamped to the first/last line.
+func (l *TextLayout) LineAt(y float32) int {
+	for i, ln := range l.Lines {
+		if y < ln.Y+ln.Height {
+			return i
+		}
+	}
+	return len(l.Lines) - 1
+}
+
+// IndexAt hit-tests (x,y), relative to the Text position, and returns the
+// glyph index a caret clicked there would sit before.  Clicks past the end
+// of a line return that line's Last.
+func (l *TextLayout) IndexAt(x, y float32) int {
+	if len(l.Lines) == 0 {
+		return 0
+	}
+	ln := l.Lines[l.LineAt(y)]
+	for i := ln.First; i < ln.Last; i++ {
+		g := l.Glyphs[i]
+		if x < g.X+g.Width/2 {
+			return i
+		}
+	}
+	return ln.Last
+}
+
+// GlyphAt returns the glyph whose box contains (x,y), or -1.
+func (l *TextLayout) GlyphAt(x, y float32) int {
+	for i, g := range l.Glyphs {
+		if x >= g.X && x < g.X+g.Width && y >= g.Y && y < g.Y+g.Height {
+			return i
+		}
+	}
+	return -1
+}
+
+// Caret returns the top of a caret placed before glyph i (i may equal
+// len(Glyphs) for the end of the text) and the line height.
+func (l *TextLayout) Caret(i int) (x, y, h float32) {
+	if len(l.Lines) == 0 {
+		return 0, 0, 0
+	}
+	if i < len(l.Glyphs) {
+		g := l.Glyphs[max(i, 0)]
+		ln := l.Lines[g.Line]
+		return g.X, ln.Y, ln.Height
+	}
+	ln := l.Lines[len(l.Lines)-1]
+	return ln.X + ln.Width, ln.Y, ln.Height
+}
+
+// Layout returns the cached layout, rebuilding it if anything changed.
+func (t *Text) Layout() *TextLayout {
+	if !t.laidOut {
+		t.buildLayout()
+		t.laidOut = true
+	}
+	return &t.lay
+}
+
+// buildLayout parses the markup and places every glyph, breaking lines at
+// newlines and, with word-wrap on, at the last space that fits.
+func (t *Text) buildLayout() {
+	lay := &t.lay
+	lay.Glyphs = lay.Glyphs[:0]
+	lay.Lines = lay.Lines[:0]
+	lay.Width, lay.Height = 0, 0
+
+	advance := make(map[rune]float32)
+	measure := func(r rune) float32 {
+		w, ok := advance[r]
+		if !ok {
+			w = rl.MeasureTextEx(t.font, string(r), t.size, 0).X
+			advance[r] = w
+		}
+		return w
+	}
+	lineWidth := func(gs []Glyph) float32 {
+		var w float32
+		for i, g := range gs {
+			if i > 0 {
+				w += t.spacing
+			}
+			w += g.Width
+		}
+		return w
+	}
+
+	var line []Glyph
+	index := 0
+	emit := func() {
+		t.placeLine(line, lineWidth(line))
+		line = line[:0]
+	}
+
+	for _, sp := range parseMarkup(t.content) {
+		var items []Glyph
+		if sp.icon != nil {
+			w := sp.icon.src.Width * t.size / max(sp.icon.src.Height, 1)
+			items = []Glyph{{Width: w, icon: sp.icon, style: sp.style}}
+		} else {
+			for _, r := range sp.text {
+				w := float32(0)
+				if r != '\n' {
+					w = measure(r)
+					if sp.style.bold {
+						w++
+					}
+				}
+				items = append(items, Glyph{Rune: r, Width: w, style: sp.style})
+			}
+		}
+
+		for _, g := range items {
+			g.Index = index
+			g.Height = t.size
+			index++
+			if g.icon == nil && g.Rune == '\n' {
+				emit()
+				continue
+			}
+			if t.wordWrap && t.maxWidth > 0 && len(line) > 0 &&
+				lineWidth(line)+t.spacing+g.Width > t.maxWidth {
+				if g.Rune == ' ' && g.icon == nil {
+					emit() // break at this space and drop it
+					continue
+				}
+				if cut := lastSpace(line); cut >= 0 {
+					rest := append([]Glyph(nil), line[cut+1:]...)
+					line = line[:cut]
+					emit()
+					line = append(line, rest...)
+				}
+			}
+			line = append(line, g)
+		}
+	}
+	emit()
+
+	n := float32(len(lay.Lines))
+	lay.Height = t.size*n + t.spacing*(n-1)
+}
+
+// placeLine positions one line of glyphs and appends it to the layout.
+func (t *Text) placeLine(line []Glyph, w float32) {
+	lay := &t.lay
+	var dx float32
+	switch t.align {
+	case AlignCenter:
+		dx = (t.maxWidth - w) / 2
+	case AlignRight:
+		dx = t.maxWidth - w
+	}
+	box := LineBox{
+		X:      dx,
+		Y:      float32(len(lay.Lines)) * (t.size + t.spacing),
+		Width:  w,
+		Height: t.size,
+		First:  len(lay.Glyphs),
+	}
+	x := dx
+	for _, g := range line {
+		g.X, g.Y = x, box.Y
+		g.Line = len(lay.Lines)
+		x += g.Width + t.spacing
+		lay.Glyphs = append(lay.Glyphs, g)
+	}
+	box.Last = len(lay.Glyphs)
+	lay.Lines = append(lay.Lines, box)
+	lay.Width = max(lay.Width, w)
+}
+
+// lastSpace returns the index of the last plain space in line, or -1.
+func lastSpace(line []Glyph) int {
+	for i := len(line) - 1; i >= 0; i-- {
+		if line[i].icon == nil && line[i].Rune == ' ' {
+			return i
+		}
+	}
+	return -1
+}
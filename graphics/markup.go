@@ -0,0 +1,206 @@
+// runt/graphics/markup.go
+package graphics
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// -----------------------------------------------------------------------------
+// Named colors & inline icons
+// -----------------------------------------------------------------------------
+
+var (
+	markupMu sync.Mutex
+	colors   = make(map[string]rl.Color)
+	icons    = make(map[string]icon)
+)
+
+type icon struct {
+	texture rl.Texture2D
+	src     rl.Rectangle
+}
+
+// RegisterColor makes `name` usable in [color=name] tags.  Names are
+// case-insensitive; runt registers its palette (Scarlet, Azure, …) at init.
+func RegisterColor(name string, c rl.Color) {
+	markupMu.Lock()
+	defer markupMu.Unlock()
+	colors[strings.ToLower(name)] = c
+}
+
+// NamedColor looks up a registered color name or a #rrggbb / #rrggbbaa hex
+// value.
+func NamedColor(name string) (rl.Color, bool) {
+	if strings.HasPrefix(name, "#") {
+		return hexColor(name[1:])
+	}
+	markupMu.Lock()
+	defer markupMu.Unlock()
+	c, ok := colors[strings.ToLower(name)]
+	return c, ok
+}
+
+// RegisterIcon makes region `src` of tex usable as [img=name] in Text.
+// Icons are scaled to the line height.
+func RegisterIcon(name string, tex rl.Texture2D, src rl.Rectangle) {
+	markupMu.Lock()
+	defer markupMu.Unlock()
+	icons[name] = icon{tex, src}
+}
+
+func lookupIcon(name string) (icon, bool) {
+	markupMu.Lock()
+	defer markupMu.Unlock()
+	ic, ok := icons[name]
+	return ic, ok
+}
+
+func hexColor(s string) (rl.Color, bool) {
+	if len(s) != 6 && len(s) != 8 {
+		return rl.Color{}, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return rl.Color{}, false
+	}
+	if len(s) == 6 {
+		v = v<<8 | 0xFF
+	}
+	return rl.NewColor(uint8(v>>24), uint8(v>>16), uint8(v>>8), uint8(v)), true
+}
+
+// -----------------------------------------------------------------------------
+// Markup parsing
+// -----------------------------------------------------------------------------
+
+// style is the formatting active for one run of text.
+type style struct {
+	color    rl.Color
+	hasColor bool
+	wave     bool
+	shake    bool
+	bold     bool
+}
+
+// span is a run of text in one style, or a single inline icon.
+type span struct {
+	text  string
+	style style
+	icon  *icon
+}
+
+// parseMarkup splits s into styled spans.  Supported tags:
+//
+//	[color=Name] … [/color]   palette/registered name or #rrggbb[aa]
+//	[wave] … [/wave]          characters bob up and down
+//	[shake] … [/shake]        characters jitter
+//	[b] … [/b]                faux bold
+//	[img=name]                inline icon from RegisterIcon
+//
+// "[[" is a literal "[".  Unknown or malformed tags are kept as text.
+func parseMarkup(s string) []span {
+	var (
+		out    []span
+		cur    strings.Builder
+		colorS []rl.Color
+		st     style
+		waves  int
+		shakes int
+		bolds  int
+	)
+	flush := func() {
+		if cur.Len() > 0 {
+			out = append(out, span{text: cur.String(), style: st})
+			cur.Reset()
+		}
+	}
+	restyle := func() {
+		flush()
+		st = style{wave: waves > 0, shake: shakes > 0, bold: bolds > 0}
+		if n := len(colorS); n > 0 {
+			st.color, st.hasColor = colorS[n-1], true
+		}
+	}
+
+	for len(s) > 0 {
+		if strings.HasPrefix(s, "[[") {
+			cur.WriteByte('[')
+			s = s[2:]
+			continue
+		}
+		if s[0] != '[' {
+			i := strings.IndexByte(s, '[')
+			if i < 0 {
+				i = len(s)
+			}
+			cur.WriteString(s[:i])
+			s = s[i:]
+			continue
+		}
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			cur.WriteString(s)
+			break
+		}
+		tag, arg, _ := strings.Cut(s[1:end], "=")
+		ok := true
+		switch strings.ToLower(tag) {
+		case "color":
+			if c, found := NamedColor(arg); found {
+				colorS = append(colorS, c)
+			} else {
+				ok = false
+			}
+		case "/color":
+			if len(colorS) > 0 {
+				colorS = colorS[:len(colorS)-1]
+			}
+		case "wave":
+			waves++
+		case "/wave":
+			waves = max(waves-1, 0)
+		case "shake":
+			shakes++
+		case "/shake":
+			shakes = max(shakes-1, 0)
+		case "b":
+			bolds++
+		case "/b":
+			bolds = max(bolds-1, 0)
+		case "img":
+			if ic, found := lookupIcon(arg); found {
+				flush()
+				out = append(out, span{style: st, icon: &ic})
+				s = s[end+1:]
+				continue
+			}
+			ok = false
+		default:
+			ok = false
+		}
+		if !ok {
+			// not a tag: keep the bracket and scan on, so a stray "["
+			// doesn't swallow a real tag after it
+			cur.WriteByte('[')
+			s = s[1:]
+			continue
+		}
+		restyle()
+		s = s[end+1:]
+	}
+	flush()
+	return out
+}
+
+// StripMarkup returns s with all recognised tags removed.
+func StripMarkup(s string) string {
+	var b strings.Builder
+	for _, sp := range parseMarkup(s) {
+		b.WriteString(sp.text)
+	}
+	return b.String()
+}
@@ -0,0 +1,186 @@
+// runt/graphics/nineslice.go
+package graphics
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// NineSlice draws a texture region at any size by keeping its four corners
+// fixed and stretching (or tiling) the edges and centre.  Used for dialog
+// boxes, buttons and other resizable UI panels.
+type NineSlice struct {
+	Texture rl.Texture2D
+
+	// Region of the texture holding the whole panel
+	Source rl.Rectangle
+
+	// Insets in source pixels: the unscaled border on each side
+	Left, Top, Right, Bottom float32
+
+	// World position of the top-left corner and the drawn size
+	X, Y          float32
+	Width, Height float32
+
+	// Parallax scrolling factors (1 == follow camera, 0 == fixed to screen)
+	ScrollX, ScrollY float32
+
+	// Tile edges/centre instead of stretching them
+	TileEdges, TileCenter bool
+
+	// Tint color & alpha override
+	Color rl.Color
+
+	visible bool
+}
+
+// NewNineSlice loads `path` through the loader and slices the whole texture
+// with the given insets.
+func NewNineSlice(path string, left, top, right, bottom float32) *NineSlice {
+	tex := loader.LoadTexture(path)
+	src := rl.NewRectangle(0, 0, float32(tex.Width), float32(tex.Height))
+	return NewNineSliceFromTexture(tex, src, left, top, right, bottom)
+}
+
+// NewNineSliceFromTexture slices the region `src` of an existing texture.
+// The initial size is the size of the region.
+func NewNineSliceFromTexture(tex rl.Texture2D, src rl.Rectangle, left, top, right, bottom float32) *NineSlice {
+	rl.SetTextureFilter(tex, rl.FilterPoint)
+	return &NineSlice{
+		Texture: tex,
+		Source:  src,
+		Left:    left, Top: top, Right: right, Bottom: bottom,
+		Width: src.Width, Height: src.Height,
+		ScrollX: 1, ScrollY: 1,
+		Color:   rl.White,
+		visible: true,
+	}
+}
+
+// NewNineSliceFromSlice uses a region and insets read by loader.LoadSlices.
+func NewNineSliceFromSlice(tex rl.Texture2D, s loader.Slice) *NineSlice {
+	src := rl.NewRectangle(float32(s.X), float32(s.Y), float32(s.Width), float32(s.Height))
+	return NewNineSliceFromTexture(tex, src,
+		float32(s.Left), float32(s.Top), float32(s.Right), float32(s.Bottom))
+}
+
+// LoadNineSlice loads the texture at `texPath` and the slice `name` from
+// an Aseprite export or sidecar JSON at `dataPath`.
+func LoadNineSlice(texPath, dataPath, name string) (*NineSlice, error) {
+	s, err := loader.LoadSlice(dataPath, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewNineSliceFromSlice(loader.LoadTexture(texPath), s), nil
+}
+
+// SetSize sets the drawn width and height.
+func (ns *NineSlice) SetSize(w, h float32) { ns.Width, ns.Height = w, h }
+
+// Update is a no-op for NineSlices.
+func (ns *NineSlice) Update(dt float64) {}
+
+// IsVisible reports current visibility.
+func (ns *NineSlice) IsVisible() bool { return ns.visible }
+
+// SetVisible toggles drawing.
+func (ns *NineSlice) SetVisible(v bool) { ns.visible = v }
+
+// SetPosition moves the top-left corner to (x,y).
+func (ns *NineSlice) SetPosition(x, y float32) { ns.X, ns.Y = x, y }
+
+// Bounds implements Bounder.
+func (ns *NineSlice) Bounds(camX, camY float32) (rl.Rectangle, bool) {
+	return rl.NewRectangle(ns.X-camX*ns.ScrollX, ns.Y-camY*ns.ScrollY, ns.Width, ns.Height), true
+}
+
+// Render draws the nine pieces.  Position and size are snapped to whole
+// pixels so seams never show; when the panel is smaller than its borders
+// the corners shrink to fit.
+func (ns *NineSlice) Render(camX, camY float32) {
+	if !ns.visible {
+		return
+	}
+	x := snap(ns.X - camX*ns.ScrollX)
+	y := snap(ns.Y - camY*ns.ScrollY)
+	w, h := snap(ns.Width), snap(ns.Height)
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	// destination borders, shrunk proportionally if they don't fit
+	l, r := fitBorders(ns.Left, ns.Right, w)
+	t, b := fitBorders(ns.Top, ns.Bottom, h)
+
+	// source columns/rows and destination columns/rows
+	s := ns.Source
+	sx := [4]float32{s.X, s.X + ns.Left, s.X + s.Width - ns.Right, s.X + s.Width}
+	sy := [4]float32{s.Y, s.Y + ns.Top, s.Y + s.Height - ns.Bottom, s.Y + s.Height}
+	dx := [4]float32{x, x + l, x + w - r, x + w}
+	dy := [4]float32{y, y + t, y + h - b, y + h}
+
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			src := rl.NewRectangle(sx[col], sy[row], sx[col+1]-sx[col], sy[row+1]-sy[row])
+			dst := rl.NewRectangle(dx[col], dy[row], dx[col+1]-dx[col], dy[row+1]-dy[row])
+			if src.Width <= 0 || src.Height <= 0 || dst.Width <= 0 || dst.Height <= 0 {
+				continue
+			}
+			tile := ns.TileEdges
+			if row == 1 && col == 1 {
+				tile = ns.TileCenter
+			}
+			// corners never tile; edges tile only along their long axis
+			tileX := tile && col == 1
+			tileY := tile && row == 1
+			ns.drawPiece(src, dst, tileX, tileY)
+		}
+	}
+}
+
+// drawPiece fills dst with src, stretching or repeating on each axis.  A
+// repeated piece that overhangs dst is drawn with a trimmed source.
+func (ns *NineSlice) drawPiece(src, dst rl.Rectangle, tileX, tileY bool) {
+	stepW, stepH := dst.Width, dst.Height
+	if tileX {
+		stepW = src.Width
+	}
+	if tileY {
+		stepH = src.Height
+	}
+	for y := dst.Y; y < dst.Y+dst.Height; y += stepH {
+		ph := min(stepH, dst.Y+dst.Height-y)
+		sh := src.Height
+		if tileY {
+			sh = ph
+		}
+		for x := dst.X; x < dst.X+dst.Width; x += stepW {
+			pw := min(stepW, dst.X+dst.Width-x)
+			sw := src.Width
+			if tileX {
+				sw = pw
+			}
+			DrawSprite(Sprite{
+				Texture: ns.Texture,
+				Src:     rl.NewRectangle(src.X, src.Y, sw, sh),
+				Dst:     rl.NewRectangle(x, y, pw, ph),
+				Color:   ns.Color,
+			})
+		}
+	}
+}
+
+// fitBorders scales two opposite insets down when they exceed size.
+func fitBorders(a, b, size float32) (float32, float32) {
+	if a+b <= size || a+b == 0 {
+		return a, b
+	}
+	k := size / (a + b)
+	a = snap(a * k)
+	return a, size - a
+}
+
+// snap rounds v down to a whole pixel.
+func snap(v float32) float32 { return float32(math.Floor(float64(v))) }
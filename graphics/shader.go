@@ -0,0 +1,225 @@
+// runt/graphics/shader.go
+package graphics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// Shader wraps a raylib shader with cached uniform locations and values.
+// Shaders loaded from files can be hot-swapped: Reload re-reads them and
+// re-applies every uniform set so far, keeping the old program if the new
+// source fails to compile.
+type Shader struct {
+	Shader rl.Shader
+
+	vsPath, fsPath string
+	modTime        time.Time
+	lastCheck      time.Time
+
+	locs     map[string]int32
+	uniforms map[string]uniform
+}
+
+// uniform is a remembered value, re-sent after a reload.
+type uniform struct {
+	value []float32
+	kind  rl.ShaderUniformDataType
+	count int32
+	tex   *rl.Texture2D
+}
+
+// ErrShaderCompile is returned when shader source fails to compile.
+var ErrShaderCompile = errors.New("graphics: shader failed to compile")
+
+// LoadShader loads a vertex/fragment pair through the loader search paths.
+// Either path may be "" to use raylib's default stage.  Panics if a file is
+// missing, like the other loader-backed constructors.
+func LoadShader(vsPath, fsPath string) *Shader {
+	s := &Shader{vsPath: vsPath, fsPath: fsPath}
+	if err := s.Reload(); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewShaderFromSource compiles shader code held in memory.
+func NewShaderFromSource(vsCode, fsCode string) (*Shader, error) {
+	s := &Shader{}
+	if err := s.compile(vsCode, fsCode); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload re-reads the shader files and swaps in the new program.
+func (s *Shader) Reload() error {
+	if s.vsPath == "" && s.fsPath == "" {
+		return nil
+	}
+	vs, fs, err := loader.LoadShaderCode(s.vsPath, s.fsPath)
+	if err != nil {
+		return err
+	}
+	if err := s.compile(vs, fs); err != nil {
+		return fmt.Errorf("%w: %s %s", err, s.vsPath, s.fsPath)
+	}
+	s.modTime = s.newestModTime()
+	return nil
+}
+
+// ReloadIfChanged reloads when either file changed on disk, checking at
+// most twice a second.  Reports whether a reload happened.  A failed
+// reload is reported once; the files are retried after the next save.
+func (s *Shader) ReloadIfChanged() (bool, error) {
+	if s.vsPath == "" && s.fsPath == "" {
+		return false, nil
+	}
+	now := time.Now()
+	if now.Sub(s.lastCheck) < time.Second/2 {
+		return false, nil
+	}
+	s.lastCheck = now
+	t := s.newestModTime()
+	if !t.After(s.modTime) {
+		return false, nil
+	}
+	s.modTime = t
+	return true, s.Reload()
+}
+
+func (s *Shader) newestModTime() time.Time {
+	var newest time.Time
+	for _, p := range []string{s.vsPath, s.fsPath} {
+		if p == "" {
+			continue
+		}
+		if t, err := loader.ModTime(p); err == nil && t.After(newest) {
+			newest = t
+		}
+	}
+	return newest
+}
+
+// compile builds the program and, on success, replaces the current one.
+func (s *Shader) compile(vs, fs string) error {
+	sh := rl.LoadShaderFromMemory(vs, fs)
+	if !rl.IsShaderValid(sh) || ((vs != "" || fs != "") && sh.ID == rl.GetShaderIdDefault()) {
+		return ErrShaderCompile
+	}
+	if s.Shader.ID != 0 && s.Shader.ID != rl.GetShaderIdDefault() {
+		rl.UnloadShader(s.Shader)
+	}
+	s.Shader = sh
+	s.locs = make(map[string]int32)
+	for name, u := range s.uniforms {
+		s.send(name, u)
+	}
+	return nil
+}
+
+// Unload frees the GPU program.
+func (s *Shader) Unload() {
+	if s.Shader.ID != rl.GetShaderIdDefault() {
+		rl.UnloadShader(s.Shader)
+	}
+	s.Shader = rl.Shader{}
+}
+
+// Loc returns the location of a uniform, or -1 if the shader lacks it.
+func (s *Shader) Loc(name string) int32 {
+	if loc, ok := s.locs[name]; ok {
+		return loc
+	}
+	loc := rl.GetShaderLocation(s.Shader, name)
+	s.locs[name] = loc
+	return loc
+}
+
+// Has reports whether the shader uses the uniform.
+func (s *Shader) Has(name string) bool { return s.Loc(name) >= 0 }
+
+// SetFloat sets a float uniform.
+func (s *Shader) SetFloat(name string, v float32) {
+	s.set(name, uniform{value: []float32{v}, kind: rl.ShaderUniformFloat, count: 1})
+}
+
+// SetVec2 sets a vec2 uniform.
+func (s *Shader) SetVec2(name string, x, y float32) {
+	s.set(name, uniform{value: []float32{x, y}, kind: rl.ShaderUniformVec2, count: 1})
+}
+
+// SetVec3 sets a vec3 uniform.
+func (s *Shader) SetVec3(name string, x, y, z float32) {
+	s.set(name, uniform{value: []float32{x, y, z}, kind: rl.ShaderUniformVec3, count: 1})
+}
+
+// SetVec4 sets a vec4 uniform.
+func (s *Shader) SetVec4(name string, x, y, z, w float32) {
+	s.set(name, uniform{value: []float32{x, y, z, w}, kind: rl.ShaderUniformVec4, count: 1})
+}
+
+// SetInt sets an int uniform.
+func (s *Shader) SetInt(name string, v int32) {
+	// raylib-go passes every uniform as []float32; ints travel as raw bits
+	bits := math.Float32frombits(uint32(v))
+	s.set(name, uniform{value: []float32{bits}, kind: rl.ShaderUniformInt, count: 1})
+}
+
+// SetColor sets a vec4 uniform to c normalised to 0–1.
+func (s *Shader) SetColor(name string, c rl.Color) {
+	s.SetVec4(name, float32(c.R)/255, float32(c.G)/255, float32(c.B)/255, float32(c.A)/255)
+}
+
+// SetVec3Array sets a vec3[] uniform from packed x,y,z triples.
+func (s *Shader) SetVec3Array(name string, xyz []float32) {
+	v := append([]float32(nil), xyz...)
+	s.set(name, uniform{value: v, kind: rl.ShaderUniformVec3, count: int32(len(v) / 3)})
+}
+
+// SetTexture binds a texture to a sampler2D uniform.
+func (s *Shader) SetTexture(name string, tex rl.Texture2D) {
+	s.set(name, uniform{tex: &tex})
+}
+
+func (s *Shader) set(name string, u uniform) {
+	if s.uniforms == nil {
+		s.uniforms = make(map[string]uniform)
+	}
+	s.uniforms[name] = u
+	s.send(name, u)
+}
+
+func (s *Shader) send(name string, u uniform) {
+	loc := s.Loc(name)
+	if loc < 0 {
+		return
+	}
+	switch {
+	case u.tex != nil:
+		rl.SetShaderValueTexture(s.Shader, loc, *u.tex)
+	case u.count > 1:
+		rl.SetShaderValueV(s.Shader, loc, u.value, u.kind, u.count)
+	default:
+		rl.SetShaderValue(s.Shader, loc, u.value, u.kind)
+	}
+}
+
+// Begin starts drawing with the shader.  Texture uniforms are re-bound
+// since raylib only keeps them until the batch is flushed.
+func (s *Shader) Begin() {
+	rl.BeginShaderMode(s.Shader)
+	for name, u := range s.uniforms {
+		if u.tex != nil {
+			s.send(name, u)
+		}
+	}
+}
+
+// End stops drawing with the shader.
+func (s *Shader) End() { rl.EndShaderMode() }
@@ -2,10 +2,9 @@
 package graphics
 
 import (
-	"strings"
+	"math"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
-	"github.com/henrypekny/runt/loader"
 )
 
 // Align controls horizontal positioning of each line.
@@ -18,31 +17,54 @@ const (
 )
 
 // Text draws one or more lines of text with optional word-wrap and alignment.
+// The content may contain inline markup (see parseMarkup): [color=Scarlet],
+// [wave], [shake], [b] and [img=coin].
 type Text struct {
-	font    rl.Font
-	content string
-	x, y    float32
-	size    float32
-	spacing float32
-	color   rl.Color
+	font     rl.Font
+	fontName string
+	content  string
+	x, y     float32
+	size     float32
+	spacing  float32
+	color    rl.Color
 
 	wordWrap bool
 	maxWidth float32
 	align    Align
 
+	// cached layout, rebuilt when content or formatting changes
+	lay     TextLayout
+	laidOut bool
+
+	// effect clock in seconds, advanced by Update
+	clock float64
+
+	// what Render shows: the first `reveal` glyphs (-1 = all) of lines
+	// [lineFrom, lineTo) (lineTo 0 = all), drawn from the top; driven by
+	// Typewriter
+	reveal           int
+	lineFrom, lineTo int
+
 	visible bool
 }
 
-// NewText creates a Text at (x,y).  It asks the loader for
-// “VT323-Regular.ttf” at the requested size, falling back
-// to the default font if needed.
+// NewText creates a Text at (x,y) in the default font (VT323 unless
+// RegisterFont("", …) replaced it).
 func NewText(s string, x, y, size float32, c rl.Color) *Text {
-	// loader.LoadFont will search all your dev/asset paths,
-	// load+cache font, and set TextureFilter to POINT for you.
-	fnt := loader.LoadFont("VT323-Regular.ttf", int32(size))
+	return NewTextFont("", s, x, y, size, c)
+}
+
+// NewTextFont creates a Text at (x,y) in the font registered as `font`,
+// falling back to the default font.  A size of 0 uses a bitmap font's
+// native size.
+func NewTextFont(font, s string, x, y, size float32, c rl.Color) *Text {
+	// FontFor asks the loader, which searches all your dev/asset paths,
+	// loads+caches the font and sets TextureFilter to POINT for you.
+	fnt, size := FontFor(font, size)
 
 	return &Text{
 		font:     fnt,
+		fontName: font,
 		content:  s,
 		x:        x,
 		y:        y,
@@ -52,88 +74,134 @@ func NewText(s string, x, y, size float32, c rl.Color) *Text {
 		wordWrap: false,
 		maxWidth: 0,
 		align:    AlignLeft,
+		reveal:   -1,
 		visible:  true,
 	}
 }
 
-func (t *Text) SetWordWrap(on bool, maxWidth float32) { t.wordWrap, t.maxWidth = on, maxWidth }
-func (t *Text) SetAlign(a Align)                      { t.align = a }
-func (t *Text) SetVisible(v bool)                     { t.visible = v }
-func (t *Text) Update(dt float64)                     {}
-func (t *Text) IsVisible() bool                       { return t.visible }
+// SetFont switches to the font registered as `name`, keeping the size.
+func (t *Text) SetFont(name string) {
+	t.font, t.size = FontFor(name, t.size)
+	t.fontName = name
+	t.laidOut = false
+}
+
+// Font returns the registered name of the Text's font.
+func (t *Text) Font() string { return t.fontName }
 
-// Render draws each line, applying camera offset, wrap and alignment.
+func (t *Text) SetWordWrap(on bool, maxWidth float32) {
+	t.wordWrap, t.maxWidth = on, maxWidth
+	t.laidOut = false
+}
+func (t *Text) SetAlign(a Align)    { t.align, t.laidOut = a, false }
+func (t *Text) SetVisible(v bool)   { t.visible = v }
+func (t *Text) IsVisible() bool     { return t.visible }
+func (t *Text) SetColor(c rl.Color) { t.color = c }
+
+// Update advances the clock that drives [wave] and [shake].
+func (t *Text) Update(dt float64) { t.clock += dt }
+
+// Bounds implements Bounder.  Wave and shake may stray a few pixels
+// outside it.
+func (t *Text) Bounds(camX, camY float32) (rl.Rectangle, bool) {
+	b := t.Layout().Bounds()
+	b.X += t.x - camX
+	b.Y += t.y - camY - float32(t.lineFrom)*(t.size+t.spacing)
+	return b, true
+}
+
+// Render draws each glyph, applying camera offset, wrap, alignment and
+// per-character effects.
 func (t *Text) Render(camX, camY float32) {
 	if !t.visible {
 		return
 	}
+	FlushBatch() // glyphs draw immediately; keep pending sprites beneath
+	lay := t.Layout()
 	x0, y0 := t.x-camX, t.y-camY
-	lines := strings.Split(t.content, "\n")
+	y0 -= float32(t.lineFrom) * (t.size + t.spacing)
 
-	if t.wordWrap && t.maxWidth > 0 {
-		var wrapped []string
-		for _, line := range lines {
-			wrapped = append(wrapped, wrapLine(line, t.font, t.size, t.spacing, t.maxWidth)...)
+	for i := range lay.Glyphs {
+		if t.reveal >= 0 && i >= t.reveal {
+			break
 		}
-		lines = wrapped
-	}
+		g := &lay.Glyphs[i]
+		if g.Line < t.lineFrom || (t.lineTo > 0 && g.Line >= t.lineTo) {
+			continue
+		}
+		dx, dy := t.effectOffset(g)
+		pos := rl.Vector2{X: x0 + g.X + dx, Y: y0 + g.Y + dy}
 
-	for i, line := range lines {
-		meas := rl.MeasureTextEx(t.font, line, t.size, t.spacing)
-		var dx float32
-		switch t.align {
-		case AlignCenter:
-			dx = (t.maxWidth - meas.X) / 2
-		case AlignRight:
-			dx = t.maxWidth - meas.X
+		col := t.color
+		if g.style.hasColor {
+			col = g.style.color
+			col.A = uint8(int(col.A) * int(t.color.A) / 255)
 		}
-		pos := rl.Vector2{X: x0 + dx, Y: y0 + float32(i)*(meas.Y+t.spacing)}
-		rl.DrawTextEx(t.font, line, pos, t.size, t.spacing, t.color)
-	}
-}
 
-func (t *Text) Width() float32 {
-	var max float32
-	for _, line := range strings.Split(t.content, "\n") {
-		if w := rl.MeasureTextEx(t.font, line, t.size, t.spacing).X; w > max {
-			max = w
+		if g.icon != nil {
+			tint := rl.White
+			tint.A = t.color.A
+			dst := rl.NewRectangle(pos.X, pos.Y, g.Width, g.Height)
+			rl.DrawTexturePro(g.icon.texture, g.icon.src, dst, rl.Vector2{}, 0, tint)
+			continue
+		}
+		if g.Rune == ' ' {
+			continue
+		}
+		rl.DrawTextCodepoint(t.font, g.Rune, pos, t.size, col)
+		if g.style.bold {
+			pos.X++
+			rl.DrawTextCodepoint(t.font, g.Rune, pos, t.size, col)
 		}
 	}
-	return max
 }
 
-func (t *Text) Height() float32 {
-	h := rl.MeasureTextEx(t.font, "M", t.size, t.spacing).Y
-	lines := float32(len(strings.Split(t.content, "\n")))
-	return h*lines + t.spacing*(lines-1)
+// effectOffset returns the [wave]/[shake] displacement of g right now.
+func (t *Text) effectOffset(g *Glyph) (float32, float32) {
+	var dx, dy float32
+	if g.style.wave {
+		dy += float32(math.Sin(t.clock*6+float64(g.Index)*0.5)) * t.size * 0.12
+	}
+	if g.style.shake {
+		// new offsets ~30 times a second, different per character
+		h := uint32(g.Index)*0x9E3779B1 ^ uint32(t.clock*30)*0x85EBCA6B
+		h ^= h >> 15
+		h *= 0x2C1B3C6D
+		h ^= h >> 12
+		amp := t.size * 0.06
+		dx += (float32(h&0xFF)/127.5 - 1) * amp
+		dy += (float32(h>>8&0xFF)/127.5 - 1) * amp
+	}
+	return dx, dy
 }
 
-func (t *Text) SetText(s string) { t.content = s }
+// Width returns the widest laid-out line, after wrapping.
+func (t *Text) Width() float32 { return t.Layout().Width }
 
-// wrapLine splits a single line into multiple so none exceed maxWidth.
-func wrapLine(s string, font rl.Font, size, spacing, maxWidth float32) []string {
-	words := strings.Fields(s)
-	if len(words) == 0 {
-		return []string{""}
-	}
-	var out []string
-	cur := ""
-	for _, w := range words {
-		next := w
-		if cur != "" {
-			next = cur + " " + w
-		}
-		if rl.MeasureTextEx(font, next, size, spacing).X > maxWidth {
-			out = append(out, cur)
-			cur = w
-		} else {
-			cur = next
-		}
+// Height returns the height of all laid-out lines, after wrapping.
+func (t *Text) Height() float32 { return t.Layout().Height }
+
+func (t *Text) SetText(s string) {
+	if s != t.content {
+		t.content, t.laidOut = s, false
 	}
-	out = append(out, cur)
-	return out
 }
 
+// Text returns the content, markup included.
+func (t *Text) Text() string { return t.content }
+
+// SetSize re-rasterises the font at `size` and re-lays out the text.
+func (t *Text) SetSize(size float32) {
+	t.font, t.size = FontFor(t.fontName, size)
+	t.laidOut = false
+}
+
+// Size returns the font size.
+func (t *Text) Size() float32 { return t.size }
+
+// SetSpacing sets the extra gap between characters and between lines.
+func (t *Text) SetSpacing(spacing float32) { t.spacing, t.laidOut = spacing, false }
+
 // SetPosition moves the Text to (x,y) in world space.
 // You can call this from BaseEntity.Render if you wrap Text in an Entity.
 func (t *Text) SetPosition(x, y float32) {
@@ -0,0 +1,790 @@
+// runt/graphics/tilemap.go
+package graphics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// TileEmpty marks a cell with no tile.
+const TileEmpty = -1
+
+// TileFlags describe how a single cell's tile is mirrored or rotated.
+// The encoding follows Tiled: the diagonal flip is applied first, then
+// the horizontal and vertical flips.
+type TileFlags uint8
+
+const (
+	FlipX        TileFlags = 1 << iota // mirror horizontally
+	FlipY                              // mirror vertically
+	FlipDiagonal                       // swap the x and y axes
+
+	Rotate90  = FlipDiagonal | FlipX // 90° clockwise
+	Rotate180 = FlipX | FlipY        // 180°
+	Rotate270 = FlipDiagonal | FlipY // 90° counter-clockwise
+)
+
+// transform converts the flags into a DrawTexturePro rotation plus source
+// mirroring.  A diagonal flip is a 90° rotation followed by a mirror, so the
+// remaining flips swap axes when it is set.
+func (f TileFlags) transform() (rotation float32, mirrorX, mirrorY bool) {
+	if f&FlipDiagonal == 0 {
+		return 0, f&FlipX != 0, f&FlipY != 0
+	}
+	return 90, f&FlipY != 0, f&FlipX == 0
+}
+
+// Tileset slices a texture into equally sized tiles, numbered left→right,
+// top→bottom starting at 0.
+type Tileset struct {
+	Texture rl.Texture2D
+
+	TileWidth, TileHeight int
+	Margin, Spacing       int // border around the sheet, gap between tiles
+
+	Columns int // tiles per row
+	Count   int // total tiles in the sheet
+}
+
+// NewTileset describes the tiles inside tex.  margin is the border around the
+// whole sheet and spacing the gap between neighbouring tiles.
+func NewTileset(tex rl.Texture2D, tileW, tileH, margin, spacing int) *Tileset {
+	rl.SetTextureFilter(tex, rl.FilterPoint)
+	cols := (int(tex.Width) - 2*margin + spacing) / (tileW + spacing)
+	rows := (int(tex.Height) - 2*margin + spacing) / (tileH + spacing)
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return &Tileset{
+		Texture:    tex,
+		TileWidth:  tileW,
+		TileHeight: tileH,
+		Margin:     margin,
+		Spacing:    spacing,
+		Columns:    cols,
+		Count:      cols * rows,
+	}
+}
+
+// Source returns the texture rectangle of the given local tile index.
+func (ts *Tileset) Source(index int) rl.Rectangle {
+	col := index % ts.Columns
+	row := index / ts.Columns
+	return rl.NewRectangle(
+		float32(ts.Margin+col*(ts.TileWidth+ts.Spacing)),
+		float32(ts.Margin+row*(ts.TileHeight+ts.Spacing)),
+		float32(ts.TileWidth),
+		float32(ts.TileHeight),
+	)
+}
+
+// tilesetRange maps a block of global tile indices onto one Tileset.
+type tilesetRange struct {
+	set   *Tileset
+	first int
+}
+
+// Tilemap draws one or more layers of tile indices on a fixed grid.
+// Only the cells inside the view are drawn; large maps are additionally
+// baked into chunk render textures so the per-frame cost is bounded by
+// the screen size rather than the map size.
+type Tilemap struct {
+	// World position of the top-left corner
+	X, Y float32
+
+	// Parallax scrolling factors (1 == follow camera exactly)
+	ScrollX, ScrollY float32
+
+	// Tint applied on top of the per-layer and per-tile tints
+	Color rl.Color
+
+	// Grid cell size in pixels
+	TileWidth, TileHeight int
+
+	columns, rows int
+	tilesets      []tilesetRange
+	layers        []*TileLayer
+
+	// chunking: size of a chunk in cells (0 = draw tiles directly),
+	// the cap on baked chunks and the chunks waiting to be baked.
+	chunkSize int
+	maxBaked  int
+	baked     int
+	bakeQueue []chunkRef
+	frame     uint64
+
+	// animated tiles: global index → frames, driven by clock
+	anims map[int]*tileAnim
+	clock float64
+
+	visible bool
+}
+
+// tileAnim cycles a tile index through a list of frames.
+type tileAnim struct {
+	frames    []int
+	durations []float64 // seconds per frame
+	total     float64
+}
+
+// current returns the frame shown at time t.
+func (a *tileAnim) current(t float64) int {
+	if a.total <= 0 {
+		return a.frames[0]
+	}
+	t = math.Mod(t, a.total)
+	for i, d := range a.durations {
+		if t < d {
+			return a.frames[i]
+		}
+		t -= d
+	}
+	return a.frames[len(a.frames)-1]
+}
+
+// Chunking defaults: maps with more cells than chunkThreshold are baked into
+// chunks of defaultChunkSize² cells.  At most maxBakesPerUpdate chunks are
+// baked per Update so streaming in new areas never stalls a frame.
+const (
+	chunkThreshold    = 128 * 128
+	defaultChunkSize  = 32
+	defaultMaxBaked   = 256
+	maxBakesPerUpdate = 4
+)
+
+// NewTilemap creates an empty columns×rows map using ts for its tiles and
+// the tileset's tile size as the grid cell size.  It starts with one layer.
+func NewTilemap(ts *Tileset, columns, rows int) *Tilemap {
+	tm := NewTilemapSize(ts.TileWidth, ts.TileHeight, columns, rows)
+	tm.AddTileset(ts)
+	tm.AddLayer("")
+	return tm
+}
+
+// NewTilemapSize creates a columns×rows map of tileW×tileH cells with no
+// tilesets and no layers; importers add both themselves.
+func NewTilemapSize(tileW, tileH, columns, rows int) *Tilemap {
+	tm := &Tilemap{
+		ScrollX: 1, ScrollY: 1,
+		Color:      rl.White,
+		TileWidth:  tileW,
+		TileHeight: tileH,
+		columns:    columns,
+		rows:       rows,
+		maxBaked:   defaultMaxBaked,
+		visible:    true,
+	}
+	if columns*rows > chunkThreshold {
+		tm.chunkSize = defaultChunkSize
+	}
+	return tm
+}
+
+// AddTileset appends another tileset and returns the global index of its
+// first tile.  Indices of earlier tilesets are unaffected.
+func (tm *Tilemap) AddTileset(ts *Tileset) int {
+	first := 0
+	if n := len(tm.tilesets); n > 0 {
+		last := tm.tilesets[n-1]
+		first = last.first + last.set.Count
+	}
+	return tm.AddTilesetAt(ts, first)
+}
+
+// AddTilesetAt registers ts so that global index `first` maps to its tile 0.
+// Importers use this to keep the index numbering of their source format.
+func (tm *Tilemap) AddTilesetAt(ts *Tileset, first int) int {
+	tm.tilesets = append(tm.tilesets, tilesetRange{set: ts, first: first})
+	sort.Slice(tm.tilesets, func(i, j int) bool {
+		return tm.tilesets[i].first < tm.tilesets[j].first
+	})
+	tm.Invalidate()
+	return first
+}
+
+// tileset resolves a global tile index to its tileset and local index.
+func (tm *Tilemap) tileset(index int) (*Tileset, int) {
+	i := sort.Search(len(tm.tilesets), func(i int) bool {
+		return tm.tilesets[i].first > index
+	}) - 1
+	if i < 0 {
+		return nil, 0
+	}
+	r := tm.tilesets[i]
+	local := index - r.first
+	if local >= r.set.Count {
+		return nil, 0
+	}
+	return r.set, local
+}
+
+// SetTileAnimation makes every cell showing tile `index` cycle through
+// frames, showing frames[i] for durations[i] seconds.  Passing no frames
+// removes the animation.  Animated cells are never baked into chunks; they
+// are drawn on top of the baked texture each frame.  frames and durations
+// must have the same length.
+func (tm *Tilemap) SetTileAnimation(index int, frames []int, durations []float64) error {
+	if len(durations) != len(frames) {
+		return fmt.Errorf("graphics: tile %d animation has %d frames but %d durations",
+			index, len(frames), len(durations))
+	}
+	if len(frames) == 0 {
+		delete(tm.anims, index)
+		tm.Invalidate()
+		return nil
+	}
+	if tm.anims == nil {
+		tm.anims = make(map[int]*tileAnim)
+	}
+	a := &tileAnim{frames: frames, durations: durations}
+	for _, d := range durations {
+		a.total += d
+	}
+	tm.anims[index] = a
+	tm.Invalidate()
+	return nil
+}
+
+// isAnimated reports whether tile `index` has an animation.
+func (tm *Tilemap) isAnimated(index int) bool {
+	_, ok := tm.anims[index]
+	return ok
+}
+
+// AddLayer appends a new empty layer on top of the existing ones.
+func (tm *Tilemap) AddLayer(name string) *TileLayer {
+	l := &TileLayer{
+		Name:    name,
+		Visible: true,
+		Color:   rl.White,
+		columns: tm.columns,
+		rows:    tm.rows,
+		tiles:   make([]int32, tm.columns*tm.rows),
+		flags:   make([]TileFlags, tm.columns*tm.rows),
+	}
+	for i := range l.tiles {
+		l.tiles[i] = TileEmpty
+	}
+	if tm.chunkSize > 0 {
+		l.initChunks(tm.chunkSize)
+	}
+	tm.layers = append(tm.layers, l)
+	return l
+}
+
+// Layer returns the layer at index i (0 is drawn first).
+func (tm *Tilemap) Layer(i int) *TileLayer { return tm.layers[i] }
+
+// LayerByName returns the first layer with the given name, or nil.
+func (tm *Tilemap) LayerByName(name string) *TileLayer {
+	for _, l := range tm.layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// Layers returns all layers in draw order.
+func (tm *Tilemap) Layers() []*TileLayer { return tm.layers }
+
+// Columns and Rows report the grid size in cells.
+func (tm *Tilemap) Columns() int { return tm.columns }
+func (tm *Tilemap) Rows() int    { return tm.rows }
+
+// Width and Height report the map size in pixels.
+func (tm *Tilemap) Width() float32  { return float32(tm.columns * tm.TileWidth) }
+func (tm *Tilemap) Height() float32 { return float32(tm.rows * tm.TileHeight) }
+
+// SetTile is a shortcut for tm.Layer(layer).SetTile(col, row, index).
+func (tm *Tilemap) SetTile(layer, col, row, index int) {
+	tm.layers[layer].SetTile(col, row, index)
+}
+
+// Tile is a shortcut for tm.Layer(layer).Tile(col, row).
+func (tm *Tilemap) Tile(layer, col, row int) int {
+	return tm.layers[layer].Tile(col, row)
+}
+
+// SetChunkSize switches chunk baking on (size>0, in cells) or off (size=0).
+// Existing baked chunks are released.
+func (tm *Tilemap) SetChunkSize(size int) {
+	tm.Unload()
+	tm.chunkSize = size
+	for _, l := range tm.layers {
+		l.chunks, l.chunkSize, l.chunkCols = nil, 0, 0
+		if size > 0 {
+			l.initChunks(size)
+		}
+	}
+}
+
+// SetMaxBakedChunks caps how many chunk textures stay resident; the least
+// recently drawn chunks are released first.
+func (tm *Tilemap) SetMaxBakedChunks(n int) { tm.maxBaked = n }
+
+// Invalidate marks every baked chunk as stale, e.g. after swapping a
+// tileset texture.
+func (tm *Tilemap) Invalidate() {
+	for _, l := range tm.layers {
+		for i := range l.chunks {
+			l.chunks[i].dirty = true
+		}
+	}
+}
+
+// Bake renders every dirty chunk of every layer right away.  Call it outside
+// of BeginDrawing (e.g. after loading a level) to avoid the first frames
+// drawing tiles directly.
+func (tm *Tilemap) Bake() {
+	for _, l := range tm.layers {
+		for i := range l.chunks {
+			if l.chunks[i].dirty || !l.chunks[i].loaded {
+				tm.bakeChunk(l, i)
+			}
+		}
+	}
+	tm.bakeQueue = tm.bakeQueue[:0]
+}
+
+// Unload releases all chunk render textures.
+func (tm *Tilemap) Unload() {
+	for _, l := range tm.layers {
+		for i := range l.chunks {
+			l.releaseChunk(i)
+		}
+	}
+	tm.baked = 0
+	tm.bakeQueue = tm.bakeQueue[:0]
+}
+
+// Update bakes chunks that were requested by the previous Render.  Baking
+// happens here rather than in Render because texture mode resets the
+// camera transform that is active while drawing.
+func (tm *Tilemap) Update(dt float64) {
+	tm.clock += dt
+
+	n := 0
+	for _, ref := range tm.bakeQueue {
+		if n == maxBakesPerUpdate {
+			break
+		}
+		c := &ref.layer.chunks[ref.index]
+		if c.loaded && !c.dirty {
+			continue
+		}
+		tm.bakeChunk(ref.layer, ref.index)
+		n++
+	}
+	tm.bakeQueue = tm.bakeQueue[:0]
+	tm.evict()
+}
+
+// IsVisible reports current visibility.
+func (tm *Tilemap) IsVisible() bool { return tm.visible }
+
+// SetVisible toggles drawing.
+func (tm *Tilemap) SetVisible(v bool) { tm.visible = v }
+
+// SetPosition moves the map's top-left corner to (x,y).
+func (tm *Tilemap) SetPosition(x, y float32) { tm.X, tm.Y = x, y }
+
+// Bounds implements Bounder.
+func (tm *Tilemap) Bounds(camX, camY float32) (rl.Rectangle, bool) {
+	var x0, y0, x1, y1 float32
+	for i, l := range tm.layers {
+		if i == 0 {
+			x0, y0, x1, y1 = l.OffsetX, l.OffsetY, l.OffsetX, l.OffsetY
+			continue
+		}
+		x0, y0 = min(x0, l.OffsetX), min(y0, l.OffsetY)
+		x1, y1 = max(x1, l.OffsetX), max(y1, l.OffsetY)
+	}
+	return rl.NewRectangle(tm.X+x0-camX*tm.ScrollX, tm.Y+y0-camY*tm.ScrollY,
+		tm.Width()+x1-x0, tm.Height()+y1-y0), true
+}
+
+// Render draws every visible layer, limited to the cells inside the view.
+func (tm *Tilemap) Render(camX, camY float32) {
+	if !tm.visible {
+		return
+	}
+	tm.frame++
+
+	// screen position of the map origin
+	ox := tm.X - camX*tm.ScrollX
+	oy := tm.Y - camY*tm.ScrollY
+
+	for _, l := range tm.layers {
+		if !l.Visible {
+			continue
+		}
+		lx, ly := ox+l.OffsetX, oy+l.OffsetY
+		c0, r0, c1, r1 := tm.visibleCells(lx, ly)
+		if c0 >= c1 || r0 >= r1 {
+			continue
+		}
+		tint := modulate(tm.Color, l.Color)
+		if tm.chunkSize > 0 {
+			tm.renderChunks(l, lx, ly, c0, r0, c1, r1, tint)
+		} else {
+			tm.renderCells(l, lx, ly, c0, r0, c1, r1, tint, false)
+		}
+	}
+}
+
+// visibleCells returns the half-open cell range [c0,c1)×[r0,r1) that
+// intersects ViewRect when the map origin sits at screen (ox,oy).
+func (tm *Tilemap) visibleCells(ox, oy float32) (c0, r0, c1, r1 int) {
+	v := ViewRect()
+	tw, th := float32(tm.TileWidth), float32(tm.TileHeight)
+
+	c0 = clampInt(floorDiv(v.X-ox, tw), 0, tm.columns)
+	r0 = clampInt(floorDiv(v.Y-oy, th), 0, tm.rows)
+	c1 = clampInt(floorDiv(v.X+v.Width-ox, tw)+1, 0, tm.columns)
+	r1 = clampInt(floorDiv(v.Y+v.Height-oy, th)+1, 0, tm.rows)
+	return
+}
+
+// renderCells draws the tiles of l in the given cell range one by one.
+// With staticOnly set, animated tiles are skipped (used when baking).
+func (tm *Tilemap) renderCells(l *TileLayer, ox, oy float32, c0, r0, c1, r1 int, tint rl.Color, staticOnly bool) {
+	for row := r0; row < r1; row++ {
+		i := row*l.columns + c0
+		for col := c0; col < c1; col, i = col+1, i+1 {
+			idx := l.tiles[i]
+			if idx == TileEmpty || (staticOnly && tm.isAnimated(int(idx))) {
+				continue
+			}
+			tm.drawCell(l, i, ox, oy, tint)
+		}
+	}
+}
+
+// drawCell draws cell i of layer l for a map origin at screen (ox,oy).
+func (tm *Tilemap) drawCell(l *TileLayer, i int, ox, oy float32, tint rl.Color) {
+	col, row := i%l.columns, i/l.columns
+	tm.drawTile(int(l.tiles[i]), l.flags[i], modulate(tint, l.tint(i)),
+		ox+float32(col*tm.TileWidth), oy+float32(row*tm.TileHeight))
+}
+
+// renderChunks draws baked chunk textures for the given cell range, falling
+// back to direct tile drawing for chunks that are not baked yet.
+func (tm *Tilemap) renderChunks(l *TileLayer, ox, oy float32, c0, r0, c1, r1 int, tint rl.Color) {
+	cs := tm.chunkSize
+	tw, th := float32(tm.TileWidth), float32(tm.TileHeight)
+	for cy := r0 / cs; cy <= (r1-1)/cs; cy++ {
+		for cx := c0 / cs; cx <= (c1-1)/cs; cx++ {
+			idx := cy*l.chunkCols + cx
+			c := &l.chunks[idx]
+			if c.empty && !c.dirty {
+				continue
+			}
+			x := ox + float32(cx*cs)*tw
+			y := oy + float32(cy*cs)*th
+			if !c.loaded || c.dirty {
+				tm.bakeQueue = append(tm.bakeQueue, chunkRef{l, idx})
+				tm.renderCells(l, ox, oy,
+					maxInt(c0, cx*cs), maxInt(r0, cy*cs),
+					minInt(c1, (cx+1)*cs), minInt(r1, (cy+1)*cs), tint, false)
+				continue
+			}
+			c.lastDrawn = tm.frame
+			tex := c.target.Texture
+			// render textures are stored upside down
+			src := rl.NewRectangle(0, 0, float32(tex.Width), -float32(tex.Height))
+			dst := rl.NewRectangle(x, y, float32(tex.Width), float32(tex.Height))
+			DrawSprite(Sprite{Texture: tex, Src: src, Dst: dst, Color: tint})
+
+			for _, ci := range c.animated {
+				tm.drawCell(l, int(ci), ox, oy, tint)
+			}
+		}
+	}
+}
+
+// drawTile draws one tile with its top-left cell corner at (x,y).  Tiles
+// larger than the grid cell are anchored to the cell's bottom-left corner.
+func (tm *Tilemap) drawTile(index int, flags TileFlags, tint rl.Color, x, y float32) {
+	if a, ok := tm.anims[index]; ok {
+		index = a.current(tm.clock)
+	}
+	ts, local := tm.tileset(index)
+	if ts == nil {
+		return
+	}
+	src := ts.Source(local)
+	rot, mx, my := flags.transform()
+	if mx {
+		src.Width = -src.Width
+	}
+	if my {
+		src.Height = -src.Height
+	}
+	w, h := float32(ts.TileWidth), float32(ts.TileHeight)
+	dst := rl.NewRectangle(x+w/2, y+float32(tm.TileHeight)-h/2, w, h)
+	DrawSprite(Sprite{Texture: ts.Texture, Src: src, Dst: dst, Origin: rl.NewVector2(w/2, h/2), Rotation: rot, Color: tint})
+}
+
+// bakeChunk renders chunk i of layer l into its render texture.
+func (tm *Tilemap) bakeChunk(l *TileLayer, i int) {
+	c := &l.chunks[i]
+	cs := tm.chunkSize
+	cx, cy := i%l.chunkCols, i/l.chunkCols
+	c0, r0 := cx*cs, cy*cs
+	c1, r1 := minInt(c0+cs, l.columns), minInt(r0+cs, l.rows)
+
+	c.empty = true
+	c.animated = c.animated[:0]
+	for row := r0; row < r1; row++ {
+		for col := c0; col < c1; col++ {
+			i := row*l.columns + col
+			if idx := l.tiles[i]; idx != TileEmpty {
+				c.empty = false
+				if tm.isAnimated(int(idx)) {
+					c.animated = append(c.animated, int32(i))
+				}
+			}
+		}
+	}
+	c.dirty = false
+	if c.empty {
+		if l.releaseChunk(i) {
+			tm.baked--
+		}
+		return
+	}
+
+	if !c.loaded {
+		c.target = rl.LoadRenderTexture(
+			int32((c1-c0)*tm.TileWidth), int32((r1-r0)*tm.TileHeight))
+		rl.SetTextureFilter(c.target.Texture, rl.FilterPoint)
+		c.loaded = true
+		tm.baked++
+	}
+	c.lastDrawn = tm.frame
+
+	defer suspendBatch()()
+	rl.BeginTextureMode(c.target)
+	rl.ClearBackground(rl.Blank)
+	ox := -float32(c0 * tm.TileWidth)
+	oy := -float32(r0 * tm.TileHeight)
+	tm.renderCells(l, ox, oy, c0, r0, c1, r1, rl.White, true)
+	rl.EndTextureMode()
+}
+
+// evict releases the least recently drawn chunks while over the cap.
+func (tm *Tilemap) evict() {
+	for tm.maxBaked > 0 && tm.baked > tm.maxBaked {
+		var oldest *TileLayer
+		oldestIdx := -1
+		var stamp uint64
+		for _, l := range tm.layers {
+			for i := range l.chunks {
+				c := &l.chunks[i]
+				if c.loaded && (oldestIdx < 0 || c.lastDrawn < stamp) {
+					oldest, oldestIdx, stamp = l, i, c.lastDrawn
+				}
+			}
+		}
+		if oldestIdx < 0 {
+			return
+		}
+		if oldest.releaseChunk(oldestIdx) {
+			tm.baked--
+		}
+	}
+}
+
+// TileLayer is one grid of tile indices inside a Tilemap.
+type TileLayer struct {
+	Name    string
+	Visible bool
+	Color   rl.Color // layer tint
+
+	// Pixel offset of the layer from the map position, e.g. from a
+	// Tiled or LDtk layer offset
+	OffsetX, OffsetY float32
+
+	columns, rows int
+	tiles         []int32
+	flags         []TileFlags
+	tints         []rl.Color // allocated on the first SetTint
+
+	chunks    []tileChunk
+	chunkSize int
+	chunkCols int
+}
+
+// tileChunk is one baked block of cells.
+type tileChunk struct {
+	target    rl.RenderTexture2D
+	loaded    bool // target holds a GPU texture
+	dirty     bool // cells changed since the last bake
+	empty     bool // no tiles at all; nothing to draw
+	lastDrawn uint64
+	animated  []int32 // cells drawn live on top of the baked texture
+}
+
+// chunkRef identifies a chunk waiting to be baked.
+type chunkRef struct {
+	layer *TileLayer
+	index int
+}
+
+func (l *TileLayer) initChunks(size int) {
+	l.chunkSize = size
+	l.chunkCols = (l.columns + size - 1) / size
+	chunkRows := (l.rows + size - 1) / size
+	l.chunks = make([]tileChunk, l.chunkCols*chunkRows)
+	for i := range l.chunks {
+		l.chunks[i].dirty = true
+	}
+}
+
+// releaseChunk frees the render texture of chunk i, reporting whether
+// there was one.
+func (l *TileLayer) releaseChunk(i int) bool {
+	c := &l.chunks[i]
+	if !c.loaded {
+		return false
+	}
+	rl.UnloadRenderTexture(c.target)
+	c.loaded = false
+	return true
+}
+
+// Columns and Rows report the grid size in cells.
+func (l *TileLayer) Columns() int { return l.columns }
+func (l *TileLayer) Rows() int    { return l.rows }
+
+// inside reports whether (col,row) is on the grid.
+func (l *TileLayer) inside(col, row int) bool {
+	return col >= 0 && row >= 0 && col < l.columns && row < l.rows
+}
+
+// Tile returns the tile index at (col,row), or TileEmpty.
+func (l *TileLayer) Tile(col, row int) int {
+	if !l.inside(col, row) {
+		return TileEmpty
+	}
+	return int(l.tiles[row*l.columns+col])
+}
+
+// SetTile places tile `index` at (col,row) and clears its flags.
+func (l *TileLayer) SetTile(col, row, index int) {
+	l.SetTileFlags(col, row, index, 0)
+}
+
+// SetTileFlags places tile `index` at (col,row) flipped/rotated by flags.
+func (l *TileLayer) SetTileFlags(col, row, index int, flags TileFlags) {
+	if !l.inside(col, row) {
+		return
+	}
+	i := row*l.columns + col
+	l.tiles[i] = int32(index)
+	l.flags[i] = flags
+	l.touch(col, row)
+}
+
+// Flags returns the flip/rotation flags at (col,row).
+func (l *TileLayer) Flags(col, row int) TileFlags {
+	if !l.inside(col, row) {
+		return 0
+	}
+	return l.flags[row*l.columns+col]
+}
+
+// SetTint colours a single cell.
+func (l *TileLayer) SetTint(col, row int, c rl.Color) {
+	if !l.inside(col, row) {
+		return
+	}
+	if l.tints == nil {
+		l.tints = make([]rl.Color, len(l.tiles))
+		for i := range l.tints {
+			l.tints[i] = rl.White
+		}
+	}
+	l.tints[row*l.columns+col] = c
+	l.touch(col, row)
+}
+
+// Clear empties every cell.
+func (l *TileLayer) Clear() {
+	for i := range l.tiles {
+		l.tiles[i] = TileEmpty
+		l.flags[i] = 0
+	}
+	l.tints = nil
+	for i := range l.chunks {
+		l.chunks[i].dirty = true
+	}
+}
+
+func (l *TileLayer) tint(i int) rl.Color {
+	if l.tints == nil {
+		return rl.White
+	}
+	return l.tints[i]
+}
+
+// touch marks the chunk containing (col,row) for re-baking.
+func (l *TileLayer) touch(col, row int) {
+	if l.chunks == nil {
+		return
+	}
+	l.chunks[(row/l.chunkSize)*l.chunkCols+col/l.chunkSize].dirty = true
+}
+
+// -----------------------------------------------------------------------------
+// small helpers
+// -----------------------------------------------------------------------------
+
+// modulate multiplies two colors channel by channel.
+func modulate(a, b rl.Color) rl.Color {
+	return rl.NewColor(
+		uint8(uint16(a.R)*uint16(b.R)/255),
+		uint8(uint16(a.G)*uint16(b.G)/255),
+		uint8(uint16(a.B)*uint16(b.B)/255),
+		uint8(uint16(a.A)*uint16(b.A)/255),
+	)
+}
+
+func floorDiv(a, b float32) int {
+	q := int(a / b)
+	if a < 0 && float32(q)*b != a {
+		q--
+	}
+	return q
+}
+
+func clampInt(x, lo, hi int) int {
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,23 @@
+// runt/graphics/tilemap_test.go
+package graphics
+
+import "testing"
+
+func TestSetTileAnimationLengths(t *testing.T) {
+	tm := NewTilemapSize(8, 8, 2, 2)
+	if err := tm.SetTileAnimation(0, []int{0, 1}, []float64{0.1, 0.1, 0.1}); err == nil {
+		t.Error("more durations than frames accepted")
+	}
+	if tm.isAnimated(0) {
+		t.Error("rejected animation was installed")
+	}
+	if err := tm.SetTileAnimation(0, []int{0, 1}, []float64{0.1, 0.2}); err != nil {
+		t.Fatal(err)
+	}
+	if got := tm.anims[0].current(0.15); got != 1 {
+		t.Errorf("frame at 0.15s = %d, want 1", got)
+	}
+	if err := tm.SetTileAnimation(0, nil, nil); err != nil || tm.isAnimated(0) {
+		t.Errorf("removing the animation: err %v, still animated %v", err, tm.isAnimated(0))
+	}
+}
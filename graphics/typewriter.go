@@ -0,0 +1,200 @@
+// runt/graphics/typewriter.go
+package graphics
+
+// DefaultPauses are the extra delays, in seconds, after punctuation.
+var DefaultPauses = map[rune]float64{
+	'.': 0.25, '!': 0.25, '?': 0.25,
+	',': 0.1, ';': 0.1, ':': 0.1,
+}
+
+// Typewriter reveals a word-wrapped Text character by character for
+// dialogue boxes.  When the wrapped text is taller than BoxHeight it stops
+// at the end of each page until Advance is called.
+type Typewriter struct {
+	Text *Text
+
+	// Characters revealed per second
+	Rate float64
+
+	// Extra delay after each rune, in seconds (defaults to DefaultPauses)
+	Pauses map[rune]float64
+
+	// Height of the dialogue box; 0 = one page holding everything
+	BoxHeight float32
+
+	// OnChar is called for every revealed non-space character (0 for
+	// icons), e.g. to play a blip.
+	OnChar func(r rune)
+	// OnPageFull is called once when a page is revealed and more follows.
+	OnPageFull func()
+	// OnDone is called once when the last character is revealed.
+	OnDone func()
+
+	shown    int     // glyphs revealed so far
+	page     int     // current page
+	wait     float64 // time until the next glyph
+	full     bool
+	done     bool
+	notified bool // OnPageFull/OnDone already fired for this stop
+}
+
+// NewTypewriter wraps t, which should already have word-wrap set, and
+// starts revealing it from the beginning.
+func NewTypewriter(t *Text, rate float64, boxHeight float32) *Typewriter {
+	tw := &Typewriter{
+		Text:      t,
+		Rate:      rate,
+		Pauses:    DefaultPauses,
+		BoxHeight: boxHeight,
+	}
+	tw.Restart()
+	return tw
+}
+
+// SetText replaces the content and restarts the reveal.
+func (tw *Typewriter) SetText(s string) {
+	tw.Text.SetText(s)
+	tw.Restart()
+}
+
+// Restart hides everything and starts again from the first page.
+func (tw *Typewriter) Restart() {
+	tw.shown, tw.page, tw.wait = 0, 0, 0
+	tw.full, tw.done, tw.notified = false, false, false
+	tw.apply()
+}
+
+// LinesPerPage returns how many wrapped lines fit in BoxHeight.
+func (tw *Typewriter) LinesPerPage() int {
+	t := tw.Text
+	if tw.BoxHeight <= 0 {
+		return max(len(t.Layout().Lines), 1)
+	}
+	return max(int((tw.BoxHeight+t.spacing)/(t.size+t.spacing)), 1)
+}
+
+// Pages returns the number of pages the text needs.
+func (tw *Typewriter) Pages() int {
+	per := tw.LinesPerPage()
+	return max((len(tw.Text.Layout().Lines)+per-1)/per, 1)
+}
+
+// Page returns the current page, starting at 0.
+func (tw *Typewriter) Page() int { return tw.page }
+
+// PageFull reports that the current page is fully shown and more follows.
+func (tw *Typewriter) PageFull() bool { return tw.full }
+
+// Done reports that every character has been revealed.
+func (tw *Typewriter) Done() bool { return tw.done }
+
+// Skip reveals the rest of the current page at once.
+func (tw *Typewriter) Skip() {
+	if tw.full || tw.done {
+		return
+	}
+	end := tw.pageEnd()
+	for tw.shown < end {
+		tw.revealNext()
+	}
+	tw.stop()
+	tw.apply()
+}
+
+// Advance is the "next" button: it skips to the end of a page that is
+// still revealing, otherwise turns to the next page.
+func (tw *Typewriter) Advance() {
+	switch {
+	case tw.done:
+	case tw.full:
+		tw.page++
+		tw.full, tw.notified, tw.wait = false, false, 0
+		tw.apply()
+	default:
+		tw.Skip()
+	}
+}
+
+// Update reveals characters at Rate, honouring pauses, and advances the
+// Text's effect clock.
+func (tw *Typewriter) Update(dt float64) {
+	tw.Text.Update(dt)
+	if tw.full || tw.done {
+		return
+	}
+	tw.wait -= dt
+	end := tw.pageEnd()
+	for tw.wait <= 0 && tw.shown < end {
+		r := tw.revealNext()
+		step := 0.0
+		if tw.Rate > 0 {
+			step = 1 / tw.Rate
+		}
+		tw.wait += step + tw.Pauses[r]
+	}
+	if tw.shown >= end {
+		tw.stop()
+	}
+	tw.apply()
+}
+
+// revealNext shows one more glyph and fires OnChar.
+func (tw *Typewriter) revealNext() rune {
+	g := tw.Text.Layout().Glyphs[tw.shown]
+	tw.shown++
+	if tw.OnChar != nil && g.Rune != ' ' {
+		tw.OnChar(g.Rune)
+	}
+	return g.Rune
+}
+
+// stop marks the end of a page (or the text) and fires its callback once.
+func (tw *Typewriter) stop() {
+	if tw.notified {
+		return
+	}
+	tw.notified = true
+	if tw.shown >= len(tw.Text.Layout().Glyphs) {
+		tw.done = true
+		if tw.OnDone != nil {
+			tw.OnDone()
+		}
+		return
+	}
+	tw.full = true
+	if tw.OnPageFull != nil {
+		tw.OnPageFull()
+	}
+}
+
+// pageEnd is the glyph index just past the current page.
+func (tw *Typewriter) pageEnd() int {
+	glyphs := tw.Text.Layout().Glyphs
+	last := (tw.page + 1) * tw.LinesPerPage()
+	for i := tw.shown; i < len(glyphs); i++ {
+		if glyphs[i].Line >= last {
+			return i
+		}
+	}
+	return len(glyphs)
+}
+
+// apply pushes the reveal state into the Text.
+func (tw *Typewriter) apply() {
+	per := tw.LinesPerPage()
+	tw.Text.reveal = tw.shown
+	tw.Text.lineFrom = tw.page * per
+	tw.Text.lineTo = tw.Text.lineFrom + per
+}
+
+// IsVisible reports current visibility.
+func (tw *Typewriter) IsVisible() bool { return tw.Text.IsVisible() }
+
+// SetVisible toggles drawing.
+func (tw *Typewriter) SetVisible(v bool) { tw.Text.SetVisible(v) }
+
+// SetPosition moves the Text to (x,y).
+func (tw *Typewriter) SetPosition(x, y float32) { tw.Text.SetPosition(x, y) }
+
+// Render draws the revealed part of the current page.
+func (tw *Typewriter) Render(camX, camY float32) { tw.Text.Render(camX, camY) }
@@ -0,0 +1,182 @@
+// runt/interp.go
+package runt
+
+import (
+	"math"
+
+	"github.com/henrypekny/runt/graphics"
+)
+
+// -----------------------------------------------------------------------------
+// Interpolation registry
+// -----------------------------------------------------------------------------
+
+type interpKind int
+
+const (
+	interpLinear interpKind = iota
+	interpAngle             // degrees, shortest arc
+	interpColor             // rl.Color, per channel
+)
+
+// interpField is one tracked value: the state at the previous tick and the
+// real value saved while a blended one is swapped in.
+type interpField struct {
+	kind      interpKind
+	f         *float32
+	c         *Color
+	prevF     float32
+	prevC     Color
+	realF     float32
+	realC     Color
+	isApplied bool
+}
+
+// InterpSet is a list of fields blended between fixed ticks.  Snapshot
+// records them before a tick; Apply swaps in the values at Interp for
+// drawing and Restore puts the simulated values back.  In variable mode
+// Apply and Restore do nothing.
+//
+//	img := graphics.NewImage("ship.png")
+//	set.TrackAngle(&img.Rotation)
+//	set.Track(&img.Scale)
+//	set.TrackColor(&img.Color) // fades blend too
+type InterpSet struct {
+	fields []interpField
+}
+
+// Track blends *p linearly (scale, alpha, offsets…).
+func (s *InterpSet) Track(p *float32) {
+	s.fields = append(s.fields, interpField{kind: interpLinear, f: p, prevF: *p})
+}
+
+// TrackAngle blends *p as degrees along the shortest arc, so 350° → 10°
+// turns through 0° rather than all the way round.
+func (s *InterpSet) TrackAngle(p *float32) {
+	s.fields = append(s.fields, interpField{kind: interpAngle, f: p, prevF: *p})
+}
+
+// TrackColor blends every channel of *p.
+func (s *InterpSet) TrackColor(p *Color) {
+	s.fields = append(s.fields, interpField{kind: interpColor, c: p, prevC: *p})
+}
+
+// Untrack stops blending the field at p (a *float32 or *Color).
+func (s *InterpSet) Untrack(p any) {
+	for i := 0; i < len(s.fields); i++ {
+		fd := &s.fields[i]
+		if (fd.f != nil && any(fd.f) == p) || (fd.c != nil && any(fd.c) == p) {
+			s.fields = append(s.fields[:i], s.fields[i+1:]...)
+			i--
+		}
+	}
+}
+
+// Len returns the number of tracked fields.
+func (s *InterpSet) Len() int { return len(s.fields) }
+
+// Snapshot records the current values as the previous tick's.
+func (s *InterpSet) Snapshot() {
+	for i := range s.fields {
+		fd := &s.fields[i]
+		if fd.c != nil {
+			fd.prevC = *fd.c
+		} else {
+			fd.prevF = *fd.f
+		}
+	}
+}
+
+// Reset is Snapshot under another name: call it after teleporting a value
+// so it doesn't visibly slide to its new place.
+func (s *InterpSet) Reset() { s.Snapshot() }
+
+// Apply writes the blended values into the fields.  Always pair it with
+// Restore before the next tick.
+func (s *InterpSet) Apply() {
+	if !Fixed {
+		return
+	}
+	t := Interp
+	for i := range s.fields {
+		fd := &s.fields[i]
+		if fd.isApplied {
+			continue
+		}
+		fd.isApplied = true
+		switch fd.kind {
+		case interpColor:
+			fd.realC = *fd.c
+			*fd.c = graphics.ColorLerp(fd.prevC, fd.realC, t)
+		case interpAngle:
+			fd.realF = *fd.f
+			*fd.f = LerpAngle(fd.prevF, fd.realF, t)
+		default:
+			fd.realF = *fd.f
+			*fd.f = fd.prevF + (fd.realF-fd.prevF)*t
+		}
+	}
+}
+
+// Restore puts back the values Apply replaced.
+func (s *InterpSet) Restore() {
+	for i := range s.fields {
+		fd := &s.fields[i]
+		if !fd.isApplied {
+			continue
+		}
+		fd.isApplied = false
+		if fd.c != nil {
+			*fd.c = fd.realC
+		} else {
+			*fd.f = fd.realF
+		}
+	}
+}
+
+// LerpAngle blends two angles in degrees along the shortest arc.
+func LerpAngle(a, b, t float32) float32 {
+	d := float32(math.Mod(float64(b-a), 360))
+	if d > 180 {
+		d -= 360
+	} else if d < -180 {
+		d += 360
+	}
+	return a + d*t
+}
+
+// -----------------------------------------------------------------------------
+// Entity and camera hooks
+// -----------------------------------------------------------------------------
+
+// Interpolate blends *p (usually a field of the entity's Graphic) between
+// fixed ticks along with the position.
+func (e *BaseEntity) Interpolate(p *float32) { e.interp.Track(p) }
+
+// InterpolateAngle blends *p as an angle in degrees.
+func (e *BaseEntity) InterpolateAngle(p *float32) { e.interp.TrackAngle(p) }
+
+// InterpolateColor blends the channels (including alpha) of *p.
+func (e *BaseEntity) InterpolateColor(p *Color) { e.interp.TrackColor(p) }
+
+// ResetInterpolation drops the previous tick's state, so a teleport shows
+// up immediately instead of sliding.
+func (e *BaseEntity) ResetInterpolation() {
+	e.prevRawX, e.prevRawY = e.rawX, e.rawY
+	e.interp.Reset()
+}
+
+// Snapshot records the camera positions and the state of every entity
+// with a Snapshot method before a fixed tick; see Camera.View and
+// BaseEntity.Snapshot for the blended result.  World.Update calls it in
+// fixed mode, so every world that is ticked interpolates.
+func (w *World) Snapshot() {
+	for _, c := range w.cameras() {
+		c.Snapshot()
+	}
+	w.ForEach(func(e Entity) {
+		if s, ok := e.(interface{ Snapshot() }); ok {
+			s.Snapshot()
+		}
+	})
+}
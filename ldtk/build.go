@@ -0,0 +1,166 @@
+package ldtk
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
+	"github.com/henrypekny/runt/loader"
+	"github.com/henrypekny/runt/mask"
+)
+
+// Tilemap builds a graphics.Tilemap from every tile, auto and IntGrid layer
+// of the level that draws tiles.  All such layers must share one grid size.
+// The map sits at the level's world position and each layer keeps its own
+// pixel offset, like Grid does for collision.  LDtk may stack several
+// auto-layer tiles in a single cell; those spill into extra tilemap layers
+// directly above their source layer.
+func (l *Level) Tilemap() (*graphics.Tilemap, error) {
+	return l.tilemap(loadTileset)
+}
+
+// loadTileset loads the texture of def and slices it into tiles.
+func loadTileset(def *TilesetDef) *graphics.Tileset {
+	ts := graphics.NewTileset(loader.LoadTexture(def.Path),
+		def.GridSize, def.GridSize, def.Padding, def.Spacing)
+	if def.Columns > 0 {
+		ts.Columns = def.Columns
+		ts.Count = def.Columns * def.Rows
+	}
+	return ts
+}
+
+// tilemap is Tilemap with the tileset loading supplied by the caller.
+func (l *Level) tilemap(tileset func(def *TilesetDef) *graphics.Tileset) (*graphics.Tilemap, error) {
+	if err := l.Load(); err != nil {
+		return nil, err
+	}
+
+	grid := 0
+	for _, layer := range l.Layers {
+		if len(layer.Tiles) == 0 {
+			continue
+		}
+		if grid == 0 {
+			grid = layer.GridSize
+		} else if layer.GridSize != grid {
+			return nil, fmt.Errorf("ldtk: level %q: layer %q grid size %d differs from %d",
+				l.Identifier, layer.Identifier, layer.GridSize, grid)
+		}
+	}
+	if grid == 0 {
+		grid = l.project.DefaultGridSize
+	}
+	tm := graphics.NewTilemapSize(grid, grid, (l.Width+grid-1)/grid, (l.Height+grid-1)/grid)
+	tm.X, tm.Y = float32(l.WorldX), float32(l.WorldY)
+
+	first := make(map[int]int) // tileset uid → first global index
+	for _, layer := range l.Layers {
+		if len(layer.Tiles) == 0 {
+			continue
+		}
+		base, ok := first[layer.TilesetUID]
+		if !ok {
+			def := l.project.Tilesets[layer.TilesetUID]
+			if def == nil {
+				return nil, fmt.Errorf("ldtk: level %q: layer %q has no tileset", l.Identifier, layer.Identifier)
+			}
+			base = tm.AddTileset(tileset(def))
+			first[layer.TilesetUID] = base
+		}
+
+		alpha := uint8(layer.Opacity * 255)
+		var stack []*graphics.TileLayer
+		for _, t := range layer.Tiles {
+			col, row := t.X/grid, t.Y/grid
+			var dst *graphics.TileLayer
+			for _, tl := range stack {
+				if tl.Tile(col, row) == graphics.TileEmpty {
+					dst = tl
+					break
+				}
+			}
+			if dst == nil {
+				dst = tm.AddLayer(layer.Identifier)
+				dst.Visible = layer.Visible
+				dst.OffsetX, dst.OffsetY = float32(layer.OffsetX), float32(layer.OffsetY)
+				dst.Color = rl.NewColor(255, 255, 255, alpha)
+				stack = append(stack, dst)
+			}
+			var flags graphics.TileFlags
+			if t.Flip&1 != 0 {
+				flags |= graphics.FlipX
+			}
+			if t.Flip&2 != 0 {
+				flags |= graphics.FlipY
+			}
+			dst.SetTileFlags(col, row, base+t.ID, flags)
+			if t.Alpha < 1 {
+				dst.SetTint(col, row, rl.NewColor(255, 255, 255, uint8(t.Alpha*255)))
+			}
+		}
+	}
+	return tm, nil
+}
+
+// Grid builds a collision grid from an IntGrid layer.  A cell is solid when
+// its value is one of values, or non-zero if no values are given.  The grid
+// is offset by the layer offset relative to the level, as in Tilemap.
+func (l *Level) Grid(layer string, values ...int) (*mask.Grid, error) {
+	if err := l.Load(); err != nil {
+		return nil, err
+	}
+	ig := l.Layer(layer)
+	if ig == nil || ig.Type != IntGrid {
+		return nil, fmt.Errorf("ldtk: level %q: no IntGrid layer %q", l.Identifier, layer)
+	}
+	g := mask.NewGrid(ig.Columns, ig.Rows, float32(ig.GridSize), float32(ig.GridSize))
+	g.XOff, g.YOff = float32(ig.OffsetX), float32(ig.OffsetY)
+	for row := 0; row < ig.Rows; row++ {
+		for col := 0; col < ig.Columns; col++ {
+			g.SetCell(col, row, solidValue(ig.IntAt(col, row), values))
+		}
+	}
+	return g, nil
+}
+
+func solidValue(v int, values []int) bool {
+	if len(values) == 0 {
+		return v != 0
+	}
+	for _, s := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// EntitiesOf returns every entity instance with the given identifier.
+func (l *Level) EntitiesOf(identifier string) []*Entity {
+	var out []*Entity
+	for _, layer := range l.Layers {
+		for _, e := range layer.Entities {
+			if e.Identifier == identifier {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
+
+// SpawnEntities walks every entity layer bottom→top and calls the spawner
+// registered for each entity's identifier.  The "" entry, if present,
+// receives entities without a spawner of their own.
+func (l *Level) SpawnEntities(spawners map[string]func(e *Entity)) {
+	fallback := spawners[""]
+	for _, layer := range l.Layers {
+		for _, e := range layer.Entities {
+			if fn, ok := spawners[e.Identifier]; ok && e.Identifier != "" {
+				fn(e)
+			} else if fallback != nil {
+				fallback(e)
+			}
+		}
+	}
+}
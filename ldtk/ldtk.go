@@ -0,0 +1,629 @@
+// Package ldtk loads LDtk projects (.ldtk, with optional external .ldtkl
+// levels) and turns their levels into runt tilemaps, collision grids and
+// entity spawn callbacks.  Streamer keeps the levels around the camera
+// loaded in a runt.World.
+package ldtk
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// World layouts as stored in the project.
+const (
+	LayoutFree             = "Free"
+	LayoutGridVania        = "GridVania"
+	LayoutLinearHorizontal = "LinearHorizontal"
+	LayoutLinearVertical   = "LinearVertical"
+)
+
+// LayerType is the kind of a layer instance.
+type LayerType string
+
+const (
+	IntGrid   LayerType = "IntGrid"
+	Entities  LayerType = "Entities"
+	Tiles     LayerType = "Tiles"
+	AutoLayer LayerType = "AutoLayer"
+)
+
+// Project is a loaded LDtk project.  Levels of every world are flattened
+// into Levels; Level.World names the world they belong to.
+type Project struct {
+	WorldLayout     string
+	DefaultGridSize int
+	BgColor         rl.Color
+
+	Levels   []*Level
+	Tilesets map[int]*TilesetDef // by uid
+
+	dir string // directory of the project file
+}
+
+// TilesetDef is a tileset definition.
+type TilesetDef struct {
+	UID        int
+	Identifier string
+	Path       string // resolved image path
+
+	GridSize         int
+	Spacing, Padding int
+	Columns, Rows    int
+}
+
+// Level is one level of the project.  Layers run bottom→top (LDtk stores
+// them top→bottom).  Levels saved as separate files are read on the first
+// call to Load.
+type Level struct {
+	Identifier string
+	IID        string
+	UID        int
+	World      string
+
+	WorldX, WorldY int // top-left corner in world pixels
+	WorldDepth     int
+	Width, Height  int // size in pixels
+
+	BgColor    rl.Color
+	Fields     Fields
+	Layers     []*Layer
+	Neighbours []Neighbour
+
+	project      *Project
+	externalPath string
+	loaded       bool
+}
+
+// Neighbour links a level to an adjacent one.  Dir is LDtk's direction code:
+// "n", "s", "e", "w", the diagonals "ne", "nw", "se", "sw", or "<", ">", "o"
+// for depth neighbours and overlaps.
+type Neighbour struct {
+	LevelIID string
+	Dir      string
+}
+
+// Layer is a layer instance inside a level.
+type Layer struct {
+	Identifier string
+	Type       LayerType
+	Visible    bool
+	Opacity    float64
+
+	Columns, Rows    int
+	GridSize         int
+	OffsetX, OffsetY int // total pixel offset
+
+	TilesetUID int // 0 when the layer draws no tiles
+
+	IntGrid  []int  // Columns×Rows values for IntGrid layers (0 = empty)
+	Tiles    []Tile // grid or auto-layer tiles, in draw order
+	Entities []*Entity
+}
+
+// Tile is one placed tile.
+type Tile struct {
+	X, Y       int // pixel position inside the layer
+	SrcX, SrcY int // pixel position inside the tileset
+	ID         int // tile id inside the tileset
+	Flip       int // bit 0 = X, bit 1 = Y
+	Alpha      float32
+}
+
+// Entity is an entity instance with its custom fields.
+type Entity struct {
+	Identifier string
+	IID        string
+
+	X, Y           int // pivot position inside the level
+	WorldX, WorldY int // pivot position in the world
+	Width, Height  int
+	PivotX, PivotY float64
+	Tags           []string
+	Fields         Fields
+
+	Level *Level
+}
+
+// -----------------------------------------------------------------------------
+// Loading
+// -----------------------------------------------------------------------------
+
+// Load reads an .ldtk project through loader.Resolve.  External levels are
+// not read until Level.Load is called (the Streamer does this for you).
+func Load(path string) (*Project, error) {
+	full, err := loader.Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := loader.ReadFile(full)
+	if err != nil {
+		return nil, err
+	}
+	var jp jsonProject
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return nil, fmt.Errorf("ldtk: %s: %w", path, err)
+	}
+
+	p := &Project{
+		WorldLayout:     jp.WorldLayout,
+		DefaultGridSize: jp.DefaultGridSize,
+		Tilesets:        make(map[int]*TilesetDef),
+		dir:             filepath.Dir(full),
+	}
+	p.BgColor, _ = parseColor(jp.BgColor)
+
+	for _, jt := range jp.Defs.Tilesets {
+		if jt.RelPath == "" {
+			continue // embedded atlases have no image on disk
+		}
+		p.Tilesets[jt.UID] = &TilesetDef{
+			UID:        jt.UID,
+			Identifier: jt.Identifier,
+			Path:       filepath.Join(p.dir, filepath.FromSlash(jt.RelPath)),
+			GridSize:   jt.TileGridSize,
+			Spacing:    jt.Spacing,
+			Padding:    jt.Padding,
+			Columns:    jt.CWid,
+			Rows:       jt.CHei,
+		}
+	}
+
+	addLevels := func(world, layout string, levels []jsonLevel) error {
+		var cursor int // running offset for linear layouts
+		for i := range levels {
+			jl := &levels[i]
+			l := p.newLevel(jl, world)
+			switch layout {
+			case LayoutLinearHorizontal:
+				l.WorldX, l.WorldY = cursor, 0
+				cursor += l.Width
+			case LayoutLinearVertical:
+				l.WorldX, l.WorldY = 0, cursor
+				cursor += l.Height
+			}
+			if jl.LayerInstances != nil {
+				if err := l.setLayers(*jl.LayerInstances); err != nil {
+					return fmt.Errorf("ldtk: %s: %w", path, err)
+				}
+			}
+			p.Levels = append(p.Levels, l)
+		}
+		return nil
+	}
+	if err := addLevels("", jp.WorldLayout, jp.Levels); err != nil {
+		return nil, err
+	}
+	for _, w := range jp.Worlds {
+		if err := addLevels(w.Identifier, w.WorldLayout, w.Levels); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// newLevel converts a level's header; layers are added by setLayers.
+func (p *Project) newLevel(jl *jsonLevel, world string) *Level {
+	l := &Level{
+		Identifier:   jl.Identifier,
+		IID:          jl.IID,
+		UID:          jl.UID,
+		World:        world,
+		WorldX:       jl.WorldX,
+		WorldY:       jl.WorldY,
+		WorldDepth:   jl.WorldDepth,
+		Width:        jl.PxWid,
+		Height:       jl.PxHei,
+		Fields:       jl.FieldInstances,
+		project:      p,
+		externalPath: jl.ExternalRelPath,
+	}
+	l.BgColor, _ = parseColor(jl.BgColor)
+	for _, n := range jl.Neighbours {
+		l.Neighbours = append(l.Neighbours, Neighbour{LevelIID: n.LevelIID, Dir: n.Dir})
+	}
+	return l
+}
+
+// Load reads the layers of an externally saved level.  It is a no-op for
+// embedded or already loaded levels.
+func (l *Level) Load() error {
+	if l.loaded {
+		return nil
+	}
+	if l.externalPath == "" {
+		return fmt.Errorf("ldtk: level %q has no layer data", l.Identifier)
+	}
+	path := filepath.Join(l.project.dir, filepath.FromSlash(l.externalPath))
+	data, err := loader.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var jl jsonLevel
+	if err := json.Unmarshal(data, &jl); err != nil {
+		return fmt.Errorf("ldtk: %s: %w", l.externalPath, err)
+	}
+	if jl.LayerInstances == nil {
+		return fmt.Errorf("ldtk: %s: no layer instances", l.externalPath)
+	}
+	return l.setLayers(*jl.LayerInstances)
+}
+
+// Unload drops the layer data of an external level so it can be re-read
+// later; embedded levels keep theirs.
+func (l *Level) Unload() {
+	if l.externalPath != "" {
+		l.Layers, l.loaded = nil, false
+	}
+}
+
+func (l *Level) setLayers(jls []jsonLayer) error {
+	l.Layers = l.Layers[:0]
+	// LDtk lists the top layer first
+	for i := len(jls) - 1; i >= 0; i-- {
+		jl := &jls[i]
+		layer := &Layer{
+			Identifier: jl.Identifier,
+			Type:       LayerType(jl.Type),
+			Visible:    jl.Visible,
+			Opacity:    jl.Opacity,
+			Columns:    jl.CWid,
+			Rows:       jl.CHei,
+			GridSize:   jl.GridSize,
+			OffsetX:    jl.PxTotalOffsetX,
+			OffsetY:    jl.PxTotalOffsetY,
+			IntGrid:    jl.IntGridCsv,
+		}
+		if jl.TilesetDefUID != nil {
+			layer.TilesetUID = *jl.TilesetDefUID
+		}
+		tiles := jl.GridTiles
+		if len(jl.AutoLayerTiles) > 0 {
+			tiles = append(tiles, jl.AutoLayerTiles...)
+		}
+		for _, t := range tiles {
+			alpha := float32(1)
+			if t.A != nil {
+				alpha = *t.A
+			}
+			layer.Tiles = append(layer.Tiles, Tile{
+				X: t.Px[0], Y: t.Px[1],
+				SrcX: t.Src[0], SrcY: t.Src[1],
+				ID:    t.T,
+				Flip:  t.F,
+				Alpha: alpha,
+			})
+		}
+		for _, je := range jl.EntityInstances {
+			e := &Entity{
+				Identifier: je.Identifier,
+				IID:        je.IID,
+				X:          je.Px[0],
+				Y:          je.Px[1],
+				WorldX:     l.WorldX + je.Px[0],
+				WorldY:     l.WorldY + je.Px[1],
+				Width:      je.Width,
+				Height:     je.Height,
+				Tags:       je.Tags,
+				Fields:     je.FieldInstances,
+				Level:      l,
+			}
+			if len(je.Pivot) == 2 {
+				e.PivotX, e.PivotY = je.Pivot[0], je.Pivot[1]
+			}
+			layer.Entities = append(layer.Entities, e)
+		}
+		l.Layers = append(l.Layers, layer)
+	}
+	l.loaded = true
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Queries
+// -----------------------------------------------------------------------------
+
+// Level returns the level with the given identifier or iid, or nil.
+func (p *Project) Level(id string) *Level {
+	for _, l := range p.Levels {
+		if l.Identifier == id || l.IID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// LevelAt returns the level containing the world point (x,y), or nil.
+// When levels overlap (depth layouts) the one with the lowest depth wins.
+func (p *Project) LevelAt(x, y float32) *Level {
+	var best *Level
+	for _, l := range p.Levels {
+		if l.Contains(x, y) && (best == nil || l.WorldDepth < best.WorldDepth) {
+			best = l
+		}
+	}
+	return best
+}
+
+// LevelsIn returns every level overlapping the world rectangle.
+func (p *Project) LevelsIn(x, y, w, h float32) []*Level {
+	var out []*Level
+	for _, l := range p.Levels {
+		if l.Overlaps(x, y, w, h) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// NeighbourLevels resolves the level's neighbour links.
+func (l *Level) NeighbourLevels() []*Level {
+	out := make([]*Level, 0, len(l.Neighbours))
+	for _, n := range l.Neighbours {
+		if nl := l.project.Level(n.LevelIID); nl != nil {
+			out = append(out, nl)
+		}
+	}
+	return out
+}
+
+// Contains reports whether the world point lies inside the level.
+func (l *Level) Contains(x, y float32) bool {
+	return x >= float32(l.WorldX) && y >= float32(l.WorldY) &&
+		x < float32(l.WorldX+l.Width) && y < float32(l.WorldY+l.Height)
+}
+
+// Overlaps reports whether the level intersects the world rectangle.
+func (l *Level) Overlaps(x, y, w, h float32) bool {
+	return x < float32(l.WorldX+l.Width) && x+w > float32(l.WorldX) &&
+		y < float32(l.WorldY+l.Height) && y+h > float32(l.WorldY)
+}
+
+// Layer returns the layer with the given identifier, or nil.
+func (l *Level) Layer(identifier string) *Layer {
+	for _, layer := range l.Layers {
+		if layer.Identifier == identifier {
+			return layer
+		}
+	}
+	return nil
+}
+
+// IntAt returns the IntGrid value at (col,row), or 0 outside the grid.
+func (layer *Layer) IntAt(col, row int) int {
+	if col < 0 || row < 0 || col >= layer.Columns || row >= layer.Rows {
+		return 0
+	}
+	return layer.IntGrid[row*layer.Columns+col]
+}
+
+// HasTag reports whether the entity carries the given tag.
+func (e *Entity) HasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// Fields
+// -----------------------------------------------------------------------------
+
+// Field is a custom field instance.  Type is LDtk's type name, e.g. "Int",
+// "Float", "String", "Bool", "Color", "Point", "EntityRef", "FilePath",
+// "LocalEnum.Kind" or "Array<Int>".  Value is the raw JSON value.
+type Field struct {
+	Identifier string          `json:"__identifier"`
+	Type       string          `json:"__type"`
+	Value      json.RawMessage `json:"__value"`
+}
+
+// Decode unmarshals the raw value into v.
+func (f *Field) Decode(v any) error {
+	return json.Unmarshal(f.Value, v)
+}
+
+// IsNull reports whether the field has no value.
+func (f *Field) IsNull() bool {
+	return len(f.Value) == 0 || string(f.Value) == "null"
+}
+
+// Fields is a list of field instances with typed accessors that fall back to
+// a default when the field is missing, null or of another type.
+type Fields []*Field
+
+// Get returns the named field, or nil.
+func (fs Fields) Get(name string) *Field {
+	for _, f := range fs {
+		if f.Identifier == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (fs Fields) decode(name string, v any) bool {
+	f := fs.Get(name)
+	return f != nil && !f.IsNull() && f.Decode(v) == nil
+}
+
+// Int returns an Int field.
+func (fs Fields) Int(name string, def int) int {
+	var v int
+	if fs.decode(name, &v) {
+		return v
+	}
+	return def
+}
+
+// Float returns a Float (or Int) field.
+func (fs Fields) Float(name string, def float64) float64 {
+	var v float64
+	if fs.decode(name, &v) {
+		return v
+	}
+	return def
+}
+
+// Text returns a String, Enum, FilePath or multiline field.
+func (fs Fields) Text(name, def string) string {
+	var v string
+	if fs.decode(name, &v) {
+		return v
+	}
+	return def
+}
+
+// Bool returns a Bool field.
+func (fs Fields) Bool(name string, def bool) bool {
+	var v bool
+	if fs.decode(name, &v) {
+		return v
+	}
+	return def
+}
+
+// Color returns a Color field ("#RRGGBB").
+func (fs Fields) Color(name string, def rl.Color) rl.Color {
+	if c, ok := parseColor(fs.Text(name, "")); ok {
+		return c
+	}
+	return def
+}
+
+// Point returns a Point field as grid cell coordinates.
+func (fs Fields) Point(name string) (cx, cy int, ok bool) {
+	var v struct{ Cx, Cy int }
+	if fs.decode(name, &v) {
+		return v.Cx, v.Cy, true
+	}
+	return 0, 0, false
+}
+
+// EntityRef returns the iid of the entity an EntityRef field points to.
+func (fs Fields) EntityRef(name string) (iid string, ok bool) {
+	var v struct {
+		EntityIid string `json:"entityIid"`
+	}
+	if fs.decode(name, &v) && v.EntityIid != "" {
+		return v.EntityIid, true
+	}
+	return "", false
+}
+
+// Ints returns an Array<Int> field.
+func (fs Fields) Ints(name string) []int {
+	var v []int
+	fs.decode(name, &v)
+	return v
+}
+
+// Texts returns an Array<String> or Array<Enum> field.
+func (fs Fields) Texts(name string) []string {
+	var v []string
+	fs.decode(name, &v)
+	return v
+}
+
+// parseColor parses "#RRGGBB".
+func parseColor(s string) (rl.Color, bool) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return rl.Color{}, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return rl.Color{}, false
+	}
+	return rl.NewColor(uint8(v>>16), uint8(v>>8), uint8(v), 0xFF), true
+}
+
+// -----------------------------------------------------------------------------
+// JSON documents
+// -----------------------------------------------------------------------------
+
+type jsonProject struct {
+	WorldLayout     string `json:"worldLayout"`
+	DefaultGridSize int    `json:"defaultGridSize"`
+	BgColor         string `json:"bgColor"`
+	Defs            struct {
+		Tilesets []struct {
+			UID          int    `json:"uid"`
+			Identifier   string `json:"identifier"`
+			RelPath      string `json:"relPath"`
+			TileGridSize int    `json:"tileGridSize"`
+			Spacing      int    `json:"spacing"`
+			Padding      int    `json:"padding"`
+			CWid         int    `json:"__cWid"`
+			CHei         int    `json:"__cHei"`
+		} `json:"tilesets"`
+	} `json:"defs"`
+	Levels []jsonLevel `json:"levels"`
+	Worlds []struct {
+		Identifier  string      `json:"identifier"`
+		WorldLayout string      `json:"worldLayout"`
+		Levels      []jsonLevel `json:"levels"`
+	} `json:"worlds"`
+}
+
+type jsonLevel struct {
+	Identifier      string       `json:"identifier"`
+	IID             string       `json:"iid"`
+	UID             int          `json:"uid"`
+	WorldX          int          `json:"worldX"`
+	WorldY          int          `json:"worldY"`
+	WorldDepth      int          `json:"worldDepth"`
+	PxWid           int          `json:"pxWid"`
+	PxHei           int          `json:"pxHei"`
+	BgColor         string       `json:"__bgColor"`
+	ExternalRelPath string       `json:"externalRelPath"`
+	FieldInstances  Fields       `json:"fieldInstances"`
+	LayerInstances  *[]jsonLayer `json:"layerInstances"`
+	Neighbours      []struct {
+		LevelIID string `json:"levelIid"`
+		Dir      string `json:"dir"`
+	} `json:"__neighbours"`
+}
+
+type jsonLayer struct {
+	Identifier      string       `json:"__identifier"`
+	Type            string       `json:"__type"`
+	CWid            int          `json:"__cWid"`
+	CHei            int          `json:"__cHei"`
+	GridSize        int          `json:"__gridSize"`
+	Opacity         float64      `json:"__opacity"`
+	PxTotalOffsetX  int          `json:"__pxTotalOffsetX"`
+	PxTotalOffsetY  int          `json:"__pxTotalOffsetY"`
+	TilesetDefUID   *int         `json:"__tilesetDefUid"`
+	Visible         bool         `json:"visible"`
+	IntGridCsv      []int        `json:"intGridCsv"`
+	GridTiles       []jsonTile   `json:"gridTiles"`
+	AutoLayerTiles  []jsonTile   `json:"autoLayerTiles"`
+	EntityInstances []jsonEntity `json:"entityInstances"`
+}
+
+type jsonTile struct {
+	Px  [2]int   `json:"px"`
+	Src [2]int   `json:"src"`
+	F   int      `json:"f"`
+	T   int      `json:"t"`
+	A   *float32 `json:"a"`
+}
+
+type jsonEntity struct {
+	Identifier     string    `json:"__identifier"`
+	IID            string    `json:"iid"`
+	Px             [2]int    `json:"px"`
+	Pivot          []float64 `json:"__pivot"`
+	Width          int       `json:"width"`
+	Height         int       `json:"height"`
+	Tags           []string  `json:"__tags"`
+	FieldInstances Fields    `json:"fieldInstances"`
+}
@@ -0,0 +1,191 @@
+package ldtk
+
+import (
+	"github.com/henrypekny/runt"
+	"github.com/henrypekny/runt/graphics"
+	"github.com/henrypekny/runt/mask"
+)
+
+// LoadedLevel is a level that a Streamer has placed in the World.
+type LoadedLevel struct {
+	Level *Level
+
+	// Entity carries the level's Tilemap as its Graphic and, when
+	// Streamer.SolidLayer is set, its collision Grid as its Mask.
+	Entity  *runt.BaseEntity
+	Tilemap *graphics.Tilemap
+	Grid    *mask.Grid // nil without a SolidLayer
+
+	// Spawned holds whatever the Spawn callback returned; it is removed from
+	// the World together with the level.
+	Spawned []runt.Entity
+}
+
+// Streamer keeps the levels around the camera loaded in a World.  Each call
+// to Update loads every level overlapping the view (grown by Margin) plus
+// their neighbours, and unloads levels that are no longer needed.
+type Streamer struct {
+	Project *Project
+	World   *runt.World
+
+	// Layer is the World layer of the level entities.
+	Layer int
+	// SolidLayer names the IntGrid layer used for collision ("" = none);
+	// SolidValues selects the solid values (empty = any non-zero value).
+	SolidLayer  string
+	SolidValues []int
+	// Margin grows the view on every side before looking for levels.
+	Margin float32
+	// Neighbours also preloads the neighbours of every visible level.
+	Neighbours bool
+
+	// Spawn creates the runt entities for one entity instance; the result is
+	// added to the World and removed again when the level unloads.
+	Spawn func(lvl *LoadedLevel, e *Entity) []runt.Entity
+	// OnLoad and OnUnload are called after a level enters / before it
+	// leaves the World.
+	OnLoad, OnUnload func(lvl *LoadedLevel)
+	// OnError receives load failures.  A level that failed once is skipped
+	// from then on.
+	OnError func(l *Level, err error)
+
+	loaded map[*Level]*LoadedLevel
+	wanted map[*Level]bool
+	failed map[*Level]bool
+}
+
+// NewStreamer creates a Streamer that preloads neighbours.
+func NewStreamer(p *Project, w *runt.World) *Streamer {
+	return &Streamer{
+		Project:    p,
+		World:      w,
+		Neighbours: true,
+		loaded:     make(map[*Level]*LoadedLevel),
+		wanted:     make(map[*Level]bool),
+		failed:     make(map[*Level]bool),
+	}
+}
+
+// Update streams levels for a view of w×h world pixels at (x,y), usually
+// the World camera and the screen size.
+func (s *Streamer) Update(x, y, w, h float32) {
+	for l := range s.wanted {
+		delete(s.wanted, l)
+	}
+	m := s.Margin
+	for _, l := range s.Project.LevelsIn(x-m, y-m, w+2*m, h+2*m) {
+		s.wanted[l] = true
+		if s.Neighbours {
+			for _, n := range l.NeighbourLevels() {
+				s.wanted[n] = true
+			}
+		}
+	}
+
+	for l, ll := range s.loaded {
+		if !s.wanted[l] {
+			s.unload(ll)
+		}
+	}
+	for l := range s.wanted {
+		if _, ok := s.loaded[l]; !ok && !s.failed[l] {
+			s.load(l)
+		}
+	}
+}
+
+// Loaded returns the currently loaded levels.
+func (s *Streamer) Loaded() []*LoadedLevel {
+	out := make([]*LoadedLevel, 0, len(s.loaded))
+	for _, ll := range s.loaded {
+		out = append(out, ll)
+	}
+	return out
+}
+
+// Solids returns the level entities that carry a collision mask, ready to
+// pass to BaseEntity.MoveCollide.
+func (s *Streamer) Solids() []runt.Solid {
+	out := make([]runt.Solid, 0, len(s.loaded))
+	for _, ll := range s.loaded {
+		if ll.Entity.Mask != nil {
+			out = append(out, ll.Entity)
+		}
+	}
+	return out
+}
+
+// UnloadAll removes every streamed level from the World.
+func (s *Streamer) UnloadAll() {
+	for _, ll := range s.loaded {
+		s.unload(ll)
+	}
+}
+
+func (s *Streamer) load(l *Level) {
+	tm, err := l.Tilemap()
+	if err != nil {
+		s.fail(l, err)
+		return
+	}
+	ll, err := s.place(l, tm)
+	if err != nil {
+		s.fail(l, err)
+		return
+	}
+
+	s.loaded[l] = ll
+	s.World.Add(ll.Entity)
+
+	if s.Spawn != nil {
+		for _, layer := range l.Layers {
+			for _, e := range layer.Entities {
+				for _, spawned := range s.Spawn(ll, e) {
+					ll.Spawned = append(ll.Spawned, spawned)
+					s.World.Add(spawned)
+				}
+			}
+		}
+	}
+	if s.OnLoad != nil {
+		s.OnLoad(ll)
+	}
+}
+
+// place wraps tm in an entity at the level's world position, with the
+// SolidLayer grid as its mask.  Both are level-local, so layer offsets put
+// tiles and collision in the same place.
+func (s *Streamer) place(l *Level, tm *graphics.Tilemap) (*LoadedLevel, error) {
+	ent := runt.NewBaseEntity(float32(l.WorldX), float32(l.WorldY), s.Layer)
+	ent.Graphic = tm
+	ll := &LoadedLevel{Level: l, Entity: ent, Tilemap: tm}
+	if s.SolidLayer != "" {
+		g, err := l.Grid(s.SolidLayer, s.SolidValues...)
+		if err != nil {
+			return nil, err
+		}
+		ent.SetMask(g)
+		ll.Grid = g
+	}
+	return ll, nil
+}
+
+func (s *Streamer) unload(ll *LoadedLevel) {
+	if s.OnUnload != nil {
+		s.OnUnload(ll)
+	}
+	for _, e := range ll.Spawned {
+		s.World.Remove(e)
+	}
+	s.World.Remove(ll.Entity)
+	ll.Tilemap.Unload()
+	ll.Level.Unload()
+	delete(s.loaded, ll.Level)
+}
+
+func (s *Streamer) fail(l *Level, err error) {
+	s.failed[l] = true
+	if s.OnError != nil {
+		s.OnError(l, err)
+	}
+}
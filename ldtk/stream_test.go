@@ -0,0 +1,70 @@
+// runt/ldtk/stream_test.go
+package ldtk
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt"
+	"github.com/henrypekny/runt/graphics"
+)
+
+// fakeTileset stands in for loadTileset without a GPU.
+func fakeTileset(def *TilesetDef) *graphics.Tileset {
+	return &graphics.Tileset{
+		Texture:   rl.Texture2D{ID: 1, Width: 8, Height: 8},
+		TileWidth: def.GridSize, TileHeight: def.GridSize,
+		Columns: 1, Count: 1,
+	}
+}
+
+func TestStreamedOffsetLayerDrawsOnItsCollision(t *testing.T) {
+	p := &Project{DefaultGridSize: 8, Tilesets: map[int]*TilesetDef{1: {UID: 1, GridSize: 8}}}
+	walls := &Layer{
+		Identifier: "walls", Type: IntGrid, Visible: true, Opacity: 1,
+		Columns: 4, Rows: 4, GridSize: 8, OffsetX: 4, OffsetY: -2,
+		TilesetUID: 1,
+		IntGrid:    make([]int, 16),
+		Tiles:      []Tile{{X: 8, Y: 16, Alpha: 1}},
+	}
+	walls.IntGrid[2*4+1] = 1
+	l := &Level{
+		Identifier: "L", WorldX: 100, WorldY: 50, Width: 32, Height: 32,
+		Layers: []*Layer{walls}, project: p, loaded: true,
+	}
+
+	tm, err := l.tilemap(fakeTileset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewStreamer(p, runt.NewWorld())
+	s.SolidLayer = "walls"
+	ll, err := s.place(l, tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// draw the level entity the way World.Render does
+	runt.CurrentWorld = s.World
+	graphics.ViewWidth, graphics.ViewHeight = 320, 240
+	b := graphics.NewBatch()
+	b.Headless, b.Record = true, true
+	graphics.BeginBatch(b)
+	ll.Entity.Render()
+	graphics.EndBatch()
+
+	if len(b.Recorded) != 1 {
+		t.Fatalf("drew %d sprites, want 1", len(b.Recorded))
+	}
+	d := b.Recorded[0]
+	x, y := d.Dst.X-d.Origin.X, d.Dst.Y-d.Origin.Y
+	if x != 100+4+8 || y != 50-2+16 {
+		t.Errorf("tile drawn at (%g,%g), want (112,64)", x, y)
+	}
+	if !ll.Grid.CollidePoint(x+4, y+4) {
+		t.Errorf("tile drawn at (%g,%g) has no collision under it", x, y)
+	}
+	if ll.Grid.CollidePoint(x-4, y+4) {
+		t.Errorf("collision found left of the tile at (%g,%g)", x, y)
+	}
+}
@@ -0,0 +1,141 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	_ "image/png" // DecodeConfig for grid fonts
+	"os"
+	"path/filepath"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Glyph ranges for LoadFontRunes.  Combine them with append or Runes.
+var (
+	ASCII    = Range(0x20, 0x7E)
+	Latin1   = Range(0x20, 0xFF)
+	Cyrillic = Range(0x400, 0x4FF)
+)
+
+// Range returns every code point from first to last inclusive.
+func Range(first, last rune) []rune {
+	out := make([]rune, 0, last-first+1)
+	for r := first; r <= last; r++ {
+		out = append(out, r)
+	}
+	return out
+}
+
+// Runes returns the distinct code points used in texts, in first-seen
+// order.  Use it to load only the CJK glyphs a game's strings need.
+func Runes(texts ...string) []rune {
+	seen := make(map[rune]bool)
+	var out []rune
+	for _, s := range texts {
+		for _, r := range s {
+			if !seen[r] {
+				seen[r] = true
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
+
+// runeHash keys the font cache by glyph set.
+func runeHash(runes []rune) uint64 {
+	if runes == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	var b [4]byte
+	for _, r := range runes {
+		b[0], b[1], b[2], b[3] = byte(r), byte(r>>8), byte(r>>16), byte(r>>24)
+		h.Write(b[:])
+	}
+	return h.Sum64()
+}
+
+// LoadBMFont loads (and caches) an AngelCode BMFont (.fnt text format) and
+// the page image next to it.
+func LoadBMFont(path string) rl.Font {
+	mu.Lock()
+	defer mu.Unlock()
+	if f, ok := fontCache[path]; ok {
+		return f
+	}
+	full, err := Resolve(path)
+	if err != nil {
+		panic(err)
+	}
+	fnt := rl.LoadFont(full)
+	rl.SetTextureFilter(fnt.Texture, rl.FilterPoint)
+	fontCache[path] = fnt
+	return fnt
+}
+
+// LoadGridFont loads (and caches) a monospaced image font: the PNG at
+// `path` is cut into cellW×cellH cells, left→right, top→bottom, and cell i
+// holds the i-th rune of `chars`.
+func LoadGridFont(path string, cellW, cellH int, chars string) rl.Font {
+	key := fmt.Sprintf("%s#grid%dx%d#%s", path, cellW, cellH, chars)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if f, ok := fontCache[key]; ok {
+		return f
+	}
+
+	full, err := Resolve(path)
+	if err != nil {
+		panic(err)
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		panic(fmt.Errorf("loader: %s: %w", path, err))
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		panic(fmt.Errorf("loader: %s: %w", path, err))
+	}
+	cols := cfg.Width / cellW
+	if cols < 1 || cellH < 1 {
+		panic(fmt.Errorf("loader: %s: cell %dx%d larger than image", path, cellW, cellH))
+	}
+
+	// raylib only builds fonts from files, so describe the grid as a
+	// BMFont in a temp dir next to a copy of the image
+	var fnt strings.Builder
+	fmt.Fprintf(&fnt, "info face=\"%s\" size=%d\n", filepath.Base(path), cellH)
+	fmt.Fprintf(&fnt, "common lineHeight=%d base=%d scaleW=%d scaleH=%d pages=1 packed=0\n",
+		cellH, cellH, cfg.Width, cfg.Height)
+	fmt.Fprintf(&fnt, "page id=0 file=\"page.png\"\n")
+	runes := []rune(chars)
+	fmt.Fprintf(&fnt, "chars count=%d\n", len(runes))
+	for i, r := range runes {
+		x, y := i%cols*cellW, i/cols*cellH
+		fmt.Fprintf(&fnt, "char id=%d x=%d y=%d width=%d height=%d xoffset=0 yoffset=0 xadvance=%d page=0 chnl=15\n",
+			r, x, y, cellW, cellH, cellW)
+	}
+
+	dir, err := os.MkdirTemp("", "runt-gridfont-*")
+	if err != nil {
+		panic(fmt.Errorf("loader: cannot create temp for grid font: %w", err))
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "page.png"), data, 0o644); err != nil {
+		panic(fmt.Errorf("loader: cannot write grid font page: %w", err))
+	}
+	fntPath := filepath.Join(dir, "font.fnt")
+	if err := os.WriteFile(fntPath, []byte(fnt.String()), 0o644); err != nil {
+		panic(fmt.Errorf("loader: cannot write grid font: %w", err))
+	}
+
+	f := rl.LoadFont(fntPath)
+	rl.SetTextureFilter(f.Texture, rl.FilterPoint)
+	fontCache[key] = f
+	return f
+}
@@ -46,7 +46,14 @@ func init() {
 }
 
 // Resolve searches each loaderPaths entry for `path`.
+// Absolute paths are returned unchanged when the file exists.
 func Resolve(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path, nil
+		}
+		return "", fmt.Errorf("runt: asset %q not found", path)
+	}
 	for _, dir := range loaderPaths {
 		full := filepath.Join(dir, path)
 		if fi, err := os.Stat(full); err == nil && !fi.IsDir() {
@@ -56,9 +63,26 @@ func Resolve(path string) (string, error) {
 	return "", fmt.Errorf("runt: asset %q not found", path)
 }
 
+// ReadFile resolves `path` and returns its contents.
+func ReadFile(path string) ([]byte, error) {
+	full, err := Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(full)
+}
+
 // LoadFont loads (and caches) a font at the given size, using disk or embedded VT323.
+// Only the 95 printable ASCII glyphs are rasterised; see LoadFontRunes.
 func LoadFont(path string, size int32) rl.Font {
-	key := fmt.Sprintf("%s#%d", path, size)
+	return LoadFontRunes(path, size, nil)
+}
+
+// LoadFontRunes loads (and caches) a TTF/OTF font at the given size with
+// glyphs for `runes` (nil = printable ASCII).  Build rune sets with
+// Range, Runes and the ASCII/Latin1/Cyrillic tables.
+func LoadFontRunes(path string, size int32, runes []rune) rl.Font {
+	key := fmt.Sprintf("%s#%d#%x", path, size, runeHash(runes))
 
 	mu.Lock()
 	defer mu.Unlock()
@@ -86,7 +110,7 @@ func LoadFont(path string, size int32) rl.Font {
 	}
 
 	// 3) load + point-filter
-	fnt := rl.LoadFontEx(full, size, nil, 0)
+	fnt := rl.LoadFontEx(full, size, runes)
 	rl.SetTextureFilter(fnt.Texture, rl.FilterPoint)
 	fontCache[key] = fnt
 	return fnt
@@ -116,3 +140,13 @@ func LoadTexture(path string) rl.Texture2D {
 	texCache[path] = tex
 	return tex
 }
+
+// LoadImage resolves and loads a CPU-side image (not cached); the caller
+// unloads it.
+func LoadImage(path string) *rl.Image {
+	full, err := Resolve(path)
+	if err != nil {
+		panic(err)
+	}
+	return rl.LoadImage(full)
+}
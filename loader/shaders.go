@@ -0,0 +1,41 @@
+package loader
+
+import (
+	"os"
+	"time"
+)
+
+// LoadShaderCode resolves and reads a vertex/fragment shader pair.  An
+// empty path yields empty code, which raylib replaces with its default
+// stage.
+func LoadShaderCode(vsPath, fsPath string) (vs, fs string, err error) {
+	if vsPath != "" {
+		b, err := ReadFile(vsPath)
+		if err != nil {
+			return "", "", err
+		}
+		vs = string(b)
+	}
+	if fsPath != "" {
+		b, err := ReadFile(fsPath)
+		if err != nil {
+			return "", "", err
+		}
+		fs = string(b)
+	}
+	return vs, fs, nil
+}
+
+// ModTime resolves `path` and returns its modification time, for hot
+// reloading.
+func ModTime(path string) (time.Time, error) {
+	full, err := Resolve(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	fi, err := os.Stat(full)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
@@ -0,0 +1,127 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Slice is a named region of a texture with optional nine-slice insets
+// (pixels kept unscaled on each side).
+type Slice struct {
+	Name                     string
+	X, Y, Width, Height      int
+	Left, Top, Right, Bottom int
+}
+
+// LoadSlices reads slice definitions from `path`, which may be either an
+// Aseprite JSON export (meta.slices, first key of each slice is used) or a
+// sidecar file of the form
+//
+//	{"panel": {"x":0, "y":0, "w":48, "h":48, "left":8, "top":8, "right":8, "bottom":8}}
+//
+// A sidecar holding one bare object yields a single slice named "".
+func LoadSlices(path string) (map[string]Slice, error) {
+	data, err := ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	out, err := parseSlices(data)
+	if err != nil {
+		return nil, fmt.Errorf("loader: %s: %w", path, err)
+	}
+	return out, nil
+}
+
+// LoadSlice is LoadSlices followed by a lookup of `name`.
+func LoadSlice(path, name string) (Slice, error) {
+	all, err := LoadSlices(path)
+	if err != nil {
+		return Slice{}, err
+	}
+	s, ok := all[name]
+	if !ok {
+		return Slice{}, fmt.Errorf("loader: %s: no slice %q", path, name)
+	}
+	return s, nil
+}
+
+type sliceRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+type sidecarSlice struct {
+	sliceRect
+	Left   int `json:"left"`
+	Top    int `json:"top"`
+	Right  int `json:"right"`
+	Bottom int `json:"bottom"`
+}
+
+func (s sidecarSlice) slice(name string) Slice {
+	return Slice{
+		Name: name,
+		X:    s.X, Y: s.Y, Width: s.W, Height: s.H,
+		Left: s.Left, Top: s.Top, Right: s.Right, Bottom: s.Bottom,
+	}
+}
+
+func parseSlices(data []byte) (map[string]Slice, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	out := make(map[string]Slice)
+
+	// Aseprite export: {"frames": …, "meta": {"slices": [...]}}
+	if meta, ok := raw["meta"]; ok {
+		var m struct {
+			Slices []struct {
+				Name string `json:"name"`
+				Keys []struct {
+					Bounds sliceRect  `json:"bounds"`
+					Center *sliceRect `json:"center"`
+				} `json:"keys"`
+			} `json:"slices"`
+		}
+		if err := json.Unmarshal(meta, &m); err != nil {
+			return nil, err
+		}
+		for _, s := range m.Slices {
+			if len(s.Keys) == 0 {
+				continue
+			}
+			k := s.Keys[0]
+			sl := Slice{Name: s.Name, X: k.Bounds.X, Y: k.Bounds.Y, Width: k.Bounds.W, Height: k.Bounds.H}
+			if c := k.Center; c != nil {
+				// center is relative to the slice bounds
+				sl.Left, sl.Top = c.X, c.Y
+				sl.Right = k.Bounds.W - (c.X + c.W)
+				sl.Bottom = k.Bounds.H - (c.Y + c.H)
+			}
+			out[s.Name] = sl
+		}
+		return out, nil
+	}
+
+	// sidecar with a single bare slice
+	if _, ok := raw["w"]; ok {
+		var s sidecarSlice
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		out[""] = s.slice("")
+		return out, nil
+	}
+
+	for name, msg := range raw {
+		var s sidecarSlice
+		if err := json.Unmarshal(msg, &s); err != nil {
+			return nil, fmt.Errorf("slice %q: %w", name, err)
+		}
+		out[name] = s.slice(name)
+	}
+	return out, nil
+}
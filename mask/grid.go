@@ -0,0 +1,188 @@
+package mask
+
+import "math"
+
+// Grid is a tile-based mask: a bit grid of solid cells, each CellWidth ×
+// CellHeight pixels, with its top-left corner at the parent's position plus
+// (XOff, YOff).  Collision tests only visit the cells that the other mask
+// overlaps, so a single Grid can cover a whole level.
+type Grid struct {
+	parent                Parent
+	XOff, YOff            float32
+	CellWidth, CellHeight float32
+
+	columns, rows int
+	bits          []uint64
+}
+
+// TileSource is any grid of tile indices a Grid can be built from, such as
+// a graphics.TileLayer.
+type TileSource interface {
+	Columns() int
+	Rows() int
+	Tile(col, row int) int
+}
+
+// NewGrid creates an empty columns×rows grid with the given cell size.
+func NewGrid(columns, rows int, cellW, cellH float32) *Grid {
+	return &Grid{
+		CellWidth:  cellW,
+		CellHeight: cellH,
+		columns:    columns,
+		rows:       rows,
+		bits:       make([]uint64, (columns*rows+63)/64),
+	}
+}
+
+// NewGridFromTiles builds a grid matching src, marking a cell solid when
+// solid(tileIndex) is true.  A nil solid treats every non-negative index
+// (i.e. every non-empty tile) as solid.
+func NewGridFromTiles(src TileSource, cellW, cellH float32, solid func(index int) bool) *Grid {
+	g := NewGrid(src.Columns(), src.Rows(), cellW, cellH)
+	g.LoadTiles(src, solid)
+	return g
+}
+
+// LoadTiles overwrites every cell from src; see NewGridFromTiles.
+func (g *Grid) LoadTiles(src TileSource, solid func(index int) bool) {
+	if solid == nil {
+		solid = func(index int) bool { return index >= 0 }
+	}
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.columns; col++ {
+			g.SetCell(col, row, solid(src.Tile(col, row)))
+		}
+	}
+}
+
+func (g *Grid) SetParent(p Parent) {
+	g.parent = p
+}
+
+func (g *Grid) Update() {
+	// cells are edited explicitly; nothing to recalc
+}
+
+// Columns and Rows report the grid size in cells.
+func (g *Grid) Columns() int { return g.columns }
+func (g *Grid) Rows() int    { return g.rows }
+
+// Cell reports whether (col,row) is solid.  Cells outside the grid are empty.
+func (g *Grid) Cell(col, row int) bool {
+	if col < 0 || row < 0 || col >= g.columns || row >= g.rows {
+		return false
+	}
+	i := row*g.columns + col
+	return g.bits[i>>6]&(1<<(i&63)) != 0
+}
+
+// SetCell marks (col,row) solid or empty.
+func (g *Grid) SetCell(col, row int, solid bool) {
+	if col < 0 || row < 0 || col >= g.columns || row >= g.rows {
+		return
+	}
+	i := row*g.columns + col
+	if solid {
+		g.bits[i>>6] |= 1 << (i & 63)
+	} else {
+		g.bits[i>>6] &^= 1 << (i & 63)
+	}
+}
+
+// SetRect marks a w×h block of cells starting at (col,row).
+func (g *Grid) SetRect(col, row, w, h int, solid bool) {
+	for r := row; r < row+h; r++ {
+		for c := col; c < col+w; c++ {
+			g.SetCell(c, r, solid)
+		}
+	}
+}
+
+// Clear empties every cell.
+func (g *Grid) Clear() {
+	for i := range g.bits {
+		g.bits[i] = 0
+	}
+}
+
+// origin returns the world position of the grid's top-left corner.
+func (g *Grid) origin() (float32, float32) {
+	if g.parent == nil {
+		return g.XOff, g.YOff
+	}
+	return g.parent.X() + g.XOff, g.parent.Y() + g.YOff
+}
+
+// cellRange returns the half-open cell range covered by a world rectangle,
+// clipped to the grid.
+func (g *Grid) cellRange(x, y, w, h float32) (c0, r0, c1, r1 int) {
+	gx, gy := g.origin()
+	c0 = int(math.Floor(float64((x - gx) / g.CellWidth)))
+	r0 = int(math.Floor(float64((y - gy) / g.CellHeight)))
+	c1 = int(math.Ceil(float64((x + w - gx) / g.CellWidth)))
+	r1 = int(math.Ceil(float64((y + h - gy) / g.CellHeight)))
+	if c0 < 0 {
+		c0 = 0
+	}
+	if r0 < 0 {
+		r0 = 0
+	}
+	if c1 > g.columns {
+		c1 = g.columns
+	}
+	if r1 > g.rows {
+		r1 = g.rows
+	}
+	return
+}
+
+// CollideRect reports whether any solid cell overlaps the world rectangle.
+// Rectangles that merely touch a cell's edge do not collide.
+func (g *Grid) CollideRect(x, y, w, h float32) bool {
+	if w <= 0 || h <= 0 {
+		return false
+	}
+	c0, r0, c1, r1 := g.cellRange(x, y, w, h)
+	for row := r0; row < r1; row++ {
+		for col := c0; col < c1; col++ {
+			if g.Cell(col, row) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CollidePoint reports whether the world point lies in a solid cell.
+func (g *Grid) CollidePoint(x, y float32) bool {
+	gx, gy := g.origin()
+	col := int(math.Floor(float64((x - gx) / g.CellWidth)))
+	row := int(math.Floor(float64((y - gy) / g.CellHeight)))
+	return g.Cell(col, row)
+}
+
+func (g *Grid) Collide(other Mask) bool {
+	switch o := other.(type) {
+	case *Hitbox:
+		x, y, w, h := o.rect()
+		return g.CollideRect(x, y, w, h)
+	case *Grid:
+		// walk our solid cells inside the other grid's bounds
+		ox, oy := o.origin()
+		c0, r0, c1, r1 := g.cellRange(ox, oy,
+			float32(o.columns)*o.CellWidth, float32(o.rows)*o.CellHeight)
+		gx, gy := g.origin()
+		for row := r0; row < r1; row++ {
+			for col := c0; col < c1; col++ {
+				if g.Cell(col, row) && o.CollideRect(
+					gx+float32(col)*g.CellWidth, gy+float32(row)*g.CellHeight,
+					g.CellWidth, g.CellHeight) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	// unknown shapes handle grids themselves
+	return other.Collide(g)
+}
@@ -16,16 +16,16 @@ func (m *Hitbox) SetParent(p Parent) {
 }
 
 func (m *Hitbox) Collide(other Mask) bool {
-	// dispatch based on type of other; for simplicity assume other is also *Hitbox
-	if o, ok := other.(*Hitbox); ok {
-		ax := m.parent.X() + m.XOff
-		ay := m.parent.Y() + m.YOff
-		bx := o.parent.X() + o.XOff
-		by := o.parent.Y() + o.YOff
+	switch o := other.(type) {
+	case *Hitbox:
+		ax, ay, _, _ := m.rect()
+		bx, by, _, _ := o.rect()
 		return ax+m.W > bx &&
 			ay+m.H > by &&
 			ax < bx+o.W &&
 			ay < by+o.H
+	case *Grid:
+		return o.Collide(m)
 	}
 	// fallback
 	return other.Collide(m)
@@ -34,3 +34,11 @@ func (m *Hitbox) Collide(other Mask) bool {
 func (m *Hitbox) Update() {
 	// nothing to recalc for a simple box
 }
+
+// rect returns the box in world coordinates.
+func (m *Hitbox) rect() (x, y, w, h float32) {
+	if m.parent == nil {
+		return m.XOff, m.YOff, m.W, m.H
+	}
+	return m.parent.X() + m.XOff, m.parent.Y() + m.YOff, m.W, m.H
+}
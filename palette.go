@@ -1,7 +1,10 @@
 // runt/palette.go
 package runt
 
-import rl "github.com/gen2brain/raylib-go/raylib"
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
+)
 
 // Color is our alias for rl.Color; users of runt only ever see runt.Color.
 type Color = rl.Color
@@ -41,3 +44,17 @@ var Endesga16 = []Color{
 	Lime, Forest, Teal, Slate,
 	Sky, White, Cyan, Azure,
 }
+
+// Palette names usable in Text markup, e.g. "[color=Scarlet]".
+var paletteNames = map[string]Color{
+	"Sand": Sand, "Rust": Rust, "Chestnut": Chestnut, "Charcoal": Charcoal,
+	"Crimson": Crimson, "Scarlet": Scarlet, "Amber": Amber, "Mustard": Mustard,
+	"Lime": Lime, "Forest": Forest, "Teal": Teal, "Slate": Slate,
+	"Sky": Sky, "White": White, "Cyan": Cyan, "Azure": Azure,
+}
+
+func init() {
+	for name, c := range paletteNames {
+		graphics.RegisterColor(name, c)
+	}
+}
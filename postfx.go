@@ -0,0 +1,349 @@
+// runt/postfx.go
+package runt
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
+)
+
+// -----------------------------------------------------------------------------
+// Post-processing chain
+// -----------------------------------------------------------------------------
+
+// PostPass is one full-screen shader pass.  Every frame the pass receives
+// `resolution` (vec2, pixels) and `time` (float, seconds) if its shader
+// declares them.
+type PostPass struct {
+	Name    string
+	Shader  *graphics.Shader
+	Enabled bool
+
+	// Setup, if set, runs before the pass each frame to update uniforms.
+	Setup func(s *graphics.Shader)
+
+	err error // from the last hot-reload
+}
+
+// Err returns the error of the last hot-reload, nil once one succeeds.
+func (p *PostPass) Err() error { return p.err }
+
+// NewPostPass wraps a shader (usually from graphics.LoadShader) as an
+// enabled pass.
+func NewPostPass(name string, s *graphics.Shader) *PostPass {
+	return &PostPass{Name: name, Shader: s, Enabled: true}
+}
+
+// PostFX renders the frame into an offscreen target and runs it through
+// an ordered chain of passes on its way to the screen.  With no enabled
+// passes the frame is drawn straight to the backbuffer as before.
+type PostFX struct {
+	// HotReload re-reads file-backed pass shaders when they change on disk.
+	// A failed compile keeps the old program; see PostPass.Err.
+	HotReload bool
+
+	// OnReload, if set, is called after each hot-reload of a pass with its
+	// result, once per save.
+	OnReload func(p *PostPass, err error)
+
+	passes []*PostPass
+
+	scene, ping, pong rl.RenderTexture2D
+	w, h              int
+}
+
+// Add appends p to the end of the chain and returns it.
+func (fx *PostFX) Add(p *PostPass) *PostPass {
+	fx.passes = append(fx.passes, p)
+	return p
+}
+
+// Insert places p at position i in the chain.
+func (fx *PostFX) Insert(i int, p *PostPass) {
+	i = max(0, min(i, len(fx.passes)))
+	fx.passes = append(fx.passes[:i], append([]*PostPass{p}, fx.passes[i:]...)...)
+}
+
+// Remove drops the named pass.  Returns true if it was present.
+func (fx *PostFX) Remove(name string) bool {
+	for i, p := range fx.passes {
+		if p.Name == name {
+			fx.passes = append(fx.passes[:i], fx.passes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Pass returns the named pass, or nil.
+func (fx *PostFX) Pass(name string) *PostPass {
+	for _, p := range fx.passes {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Passes returns the chain in order.
+func (fx *PostFX) Passes() []*PostPass { return fx.passes }
+
+// Swap replaces the shader of the named pass at runtime.  Returns false if
+// there is no such pass.
+func (fx *PostFX) Swap(name string, s *graphics.Shader) bool {
+	p := fx.Pass(name)
+	if p == nil {
+		return false
+	}
+	p.Shader = s
+	return true
+}
+
+// Active reports whether any pass is enabled.
+func (fx *PostFX) Active() bool {
+	for _, p := range fx.passes {
+		if p.Enabled && p.Shader != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Unload frees the render targets.
+func (fx *PostFX) Unload() {
+	if fx.w > 0 {
+		rl.UnloadRenderTexture(fx.scene)
+		rl.UnloadRenderTexture(fx.ping)
+		rl.UnloadRenderTexture(fx.pong)
+	}
+	fx.w, fx.h = 0, 0
+}
+
+// begin redirects drawing into the scene target, (re)creating the targets
+// when the size changed.
+func (fx *PostFX) begin(w, h int) {
+	if w != fx.w || h != fx.h {
+		fx.Unload()
+		fx.scene = rl.LoadRenderTexture(int32(w), int32(h))
+		fx.ping = rl.LoadRenderTexture(int32(w), int32(h))
+		fx.pong = rl.LoadRenderTexture(int32(w), int32(h))
+		for _, t := range []rl.RenderTexture2D{fx.scene, fx.ping, fx.pong} {
+			rl.SetTextureFilter(t.Texture, rl.FilterPoint)
+		}
+		fx.w, fx.h = w, h
+	}
+	if fx.HotReload {
+		for _, p := range fx.passes {
+			if p.Shader == nil {
+				continue
+			}
+			ok, err := p.Shader.ReloadIfChanged()
+			if !ok {
+				continue
+			}
+			p.err = err
+			if fx.OnReload != nil {
+				fx.OnReload(p, err)
+			}
+		}
+	}
+	rl.BeginTextureMode(fx.scene)
+}
+
+// end finishes the scene and runs every enabled pass, the last one drawing
+// to the screen rectangle dst over a backbuffer cleared to bars.
+func (fx *PostFX) end(dst rl.Rectangle, bars rl.Color) {
+	rl.EndTextureMode()
+
+	var active []*PostPass
+	for _, p := range fx.passes {
+		if p.Enabled && p.Shader != nil {
+			active = append(active, p)
+		}
+	}
+
+	w, h := float32(fx.w), float32(fx.h)
+	src := rl.NewRectangle(0, 0, w, -h) // render textures are upside down
+	in := fx.scene
+	targets := [2]rl.RenderTexture2D{fx.ping, fx.pong}
+	now := float32(rl.GetTime())
+
+	for i, p := range active {
+		s := p.Shader
+		s.SetVec2("resolution", w, h)
+		s.SetFloat("time", now)
+		if p.Setup != nil {
+			p.Setup(s)
+		}
+
+		if i == len(active)-1 {
+			rl.ClearBackground(bars)
+			s.Begin()
+			rl.DrawTexturePro(in.Texture, src, dst, rl.Vector2{}, 0, rl.White)
+			s.End()
+			return
+		}
+		out := targets[i%2]
+		rl.BeginTextureMode(out)
+		rl.ClearBackground(rl.Blank)
+		s.Begin()
+		rl.DrawTextureRec(in.Texture, src, rl.Vector2{}, rl.White)
+		s.End()
+		rl.EndTextureMode()
+		in = out
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Built-in passes
+// -----------------------------------------------------------------------------
+
+// mustShader compiles one of the built-in fragment shaders.
+func mustShader(name, fs string) *graphics.Shader {
+	s, err := graphics.NewShaderFromSource("", fs)
+	if err != nil {
+		panic(fmt.Errorf("runt: built-in %s shader: %w", name, err))
+	}
+	return s
+}
+
+// NewCRTPass bends the image like a curved CRT tube and darkens the edges.
+// curvature 0 is flat; 0.1–0.3 looks right.
+func NewCRTPass(curvature float32) *PostPass {
+	p := NewPostPass("crt", mustShader("crt", crtFS))
+	p.Shader.SetFloat("curvature", curvature)
+	return p
+}
+
+// NewScanlinesPass darkens every other pixel row; intensity is 0–1.
+func NewScanlinesPass(intensity float32) *PostPass {
+	p := NewPostPass("scanlines", mustShader("scanlines", scanlinesFS))
+	p.Shader.SetFloat("intensity", intensity)
+	return p
+}
+
+// NewBloomPass adds a glow around pixels brighter than threshold (0–1).
+func NewBloomPass(threshold, intensity float32) *PostPass {
+	p := NewPostPass("bloom", mustShader("bloom", bloomFS))
+	p.Shader.SetFloat("threshold", threshold)
+	p.Shader.SetFloat("intensity", intensity)
+	return p
+}
+
+// NewPalettePass snaps every pixel to the nearest colour of palette
+// (nil = Endesga16).  At most 32 colours are used.
+func NewPalettePass(palette []Color) *PostPass {
+	if palette == nil {
+		palette = Endesga16
+	}
+	palette = palette[:min(len(palette), 32)]
+	xyz := make([]float32, 0, len(palette)*3)
+	for _, c := range palette {
+		xyz = append(xyz, float32(c.R)/255, float32(c.G)/255, float32(c.B)/255)
+	}
+	p := NewPostPass("palette", mustShader("palette", paletteFS))
+	p.Shader.SetVec3Array("palette", xyz)
+	p.Shader.SetInt("paletteSize", int32(len(palette)))
+	return p
+}
+
+// NewChromaticPass splits the red and blue channels by offset pixels,
+// increasing towards the screen edges.
+func NewChromaticPass(offset float32) *PostPass {
+	p := NewPostPass("chromatic", mustShader("chromatic", chromaticFS))
+	p.Shader.SetFloat("offset", offset)
+	return p
+}
+
+// GLSL 330 sources for the built-in passes.  raylib supplies fragTexCoord,
+// fragColor, texture0 and colDiffuse.
+const fsHeader = `#version 330
+in vec2 fragTexCoord;
+in vec4 fragColor;
+uniform sampler2D texture0;
+uniform vec4 colDiffuse;
+uniform vec2 resolution;
+uniform float time;
+out vec4 finalColor;
+`
+
+const crtFS = fsHeader + `
+uniform float curvature;
+void main() {
+	vec2 uv = fragTexCoord * 2.0 - 1.0;
+	uv *= 1.0 + curvature * dot(uv.yx, uv.yx) * 0.25;
+	uv = uv * 0.5 + 0.5;
+	if (uv.x < 0.0 || uv.x > 1.0 || uv.y < 0.0 || uv.y > 1.0) {
+		finalColor = vec4(0.0, 0.0, 0.0, 1.0);
+		return;
+	}
+	vec4 c = texture(texture0, uv);
+	vec2 e = uv * (1.0 - uv);
+	float vignette = clamp(pow(e.x * e.y * 16.0, 0.25), 0.0, 1.0);
+	finalColor = vec4(c.rgb * vignette, c.a) * colDiffuse * fragColor;
+}
+`
+
+const scanlinesFS = fsHeader + `
+uniform float intensity;
+void main() {
+	vec4 c = texture(texture0, fragTexCoord);
+	float line = mod(floor(fragTexCoord.y * resolution.y), 2.0);
+	c.rgb *= 1.0 - intensity * line;
+	finalColor = c * colDiffuse * fragColor;
+}
+`
+
+const bloomFS = fsHeader + `
+uniform float threshold;
+uniform float intensity;
+void main() {
+	vec4 base = texture(texture0, fragTexCoord);
+	vec2 px = 1.0 / resolution;
+	vec3 glow = vec3(0.0);
+	float total = 0.0;
+	for (int x = -4; x <= 4; x++) {
+		for (int y = -4; y <= 4; y++) {
+			vec3 s = texture(texture0, fragTexCoord + vec2(x, y) * px * 1.5).rgb;
+			float w = 1.0 / (1.0 + float(x * x + y * y));
+			float lum = dot(s, vec3(0.2126, 0.7152, 0.0722));
+			glow += s * step(threshold, lum) * w;
+			total += w;
+		}
+	}
+	finalColor = vec4(base.rgb + glow / total * intensity * 4.0, base.a) * colDiffuse * fragColor;
+}
+`
+
+const paletteFS = fsHeader + `
+uniform vec3 palette[32];
+uniform int paletteSize;
+void main() {
+	vec4 c = texture(texture0, fragTexCoord);
+	vec3 best = palette[0];
+	float bestDist = 1e9;
+	for (int i = 0; i < 32; i++) {
+		if (i >= paletteSize) break;
+		vec3 d = c.rgb - palette[i];
+		float dist = dot(d, d);
+		if (dist < bestDist) {
+			bestDist = dist;
+			best = palette[i];
+		}
+	}
+	finalColor = vec4(best, c.a) * colDiffuse * fragColor;
+}
+`
+
+const chromaticFS = fsHeader + `
+uniform float offset;
+void main() {
+	vec2 dir = fragTexCoord - 0.5;
+	vec2 shift = dir * length(dir) * 2.0 * offset / resolution;
+	float r = texture(texture0, fragTexCoord + shift).r;
+	vec4 g = texture(texture0, fragTexCoord);
+	float b = texture(texture0, fragTexCoord - shift).b;
+	finalColor = vec4(r, g.g, b, g.a) * colDiffuse * fragColor;
+}
+`
@@ -5,13 +5,16 @@ import (
 	"math"
 	"math/rand"
 	"time"
+
+	"github.com/henrypekny/runt/graphics"
 )
 
 // -----------------------------------------------------------------------------
 // Global state (mirrors FP.as)
 // -----------------------------------------------------------------------------
 
-// Screen dimensions (set via Resize).
+// Screen dimensions (set via Resize).  With a virtual resolution these are
+// the virtual size, not the window size.
 var (
 	Width, Height         int     // full resolution
 	HalfWidth, HalfHeight float32 // half resolution
@@ -24,7 +27,7 @@ var (
 	FrameRate    float64     // measured frames per second
 	AssignedFPS  int         // target FPS
 	Elapsed      float64     // time since last frame (seconds)
-	Rate         float64 = 1 // timescale multiplier for Elapsed
+	Rate         float64 = 1 // timescale multiplier for Elapsed and Game.Update's dt
 )
 
 // BackgroundColor is the default clear‐screen color.  Engine uses this in ClearBackground.
@@ -34,9 +37,6 @@ var BackgroundColor Color = Charcoal
 // CurrentWorld.Update/Render/Entities() etc.
 var CurrentWorld *World
 
-// Camera offset
-var CameraX, CameraY float32
-
 // Random seed state
 var (
 	_seed        int64     = time.Now().UnixNano() & 0x7FFFFFFF
@@ -48,20 +48,27 @@ var (
 // -----------------------------------------------------------------------------
 
 // Resize sets the virtual screen size and recalculates half-width/height.
+// Use Engine.SetVirtualResolution to scale it to a differently sized window.
 func Resize(w, h int) {
 	Width, Height = w, h
 	HalfWidth = float32(w) / 2
 	HalfHeight = float32(h) / 2
+	graphics.ViewWidth, graphics.ViewHeight = float32(w), float32(h)
 }
 
-// SetCamera moves the camera offset.
+// SetCamera moves the CurrentWorld's camera so its view starts at (x,y).
 func SetCamera(x, y float32) {
-	CameraX, CameraY = x, y
+	cam := CurrentWorld.Camera
+	cam.X, cam.Y = x, y
+	cam.Snapshot()
 }
 
-// ResetCamera zeroes out the camera.
+// ResetCamera returns the CurrentWorld's camera to the origin, unzoomed and
+// unrotated, with no shake.
 func ResetCamera() {
-	CameraX, CameraY = 0, 0
+	cam := CurrentWorld.Camera
+	cam.X, cam.Y, cam.Zoom, cam.Rotation, cam.Trauma = 0, 0, 1, 0, 0
+	cam.Snapshot()
 }
 
 // -----------------------------------------------------------------------------
@@ -153,17 +160,7 @@ func Lerp(a, b, t float64) float64 {
 
 // ColorLerp blends two Colors by t in [0,1].
 func ColorLerp(c1, c2 Color, t float32) Color {
-	if t <= 0 {
-		return c1
-	}
-	if t >= 1 {
-		return c2
-	}
-	r := uint8(float32(c1.R) + (float32(c2.R)-float32(c1.R))*t)
-	g := uint8(float32(c1.G) + (float32(c2.G)-float32(c1.G))*t)
-	b := uint8(float32(c1.B) + (float32(c2.B)-float32(c1.B))*t)
-	a := uint8(float32(c1.A) + (float32(c2.A)-float32(c1.A))*t)
-	return NewColor(r, g, b, a)
+	return graphics.ColorLerp(c1, c2, t)
 }
 
 // Distance between two points.
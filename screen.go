@@ -0,0 +1,147 @@
+// runt/screen.go
+package runt
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// -----------------------------------------------------------------------------
+// Virtual resolution
+// -----------------------------------------------------------------------------
+
+// ScaleMode controls how the virtual resolution (Width×Height) maps onto
+// the window.
+type ScaleMode int
+
+const (
+	// ScaleNone draws straight to the window, which is Width×Height.
+	ScaleNone ScaleMode = iota
+	// ScaleInteger scales by the largest whole factor that fits and
+	// letterboxes the rest, so every virtual pixel is an exact square.
+	ScaleInteger
+	// ScaleFit scales by the largest factor that fits, keeping the aspect
+	// ratio, and letterboxes the rest.
+	ScaleFit
+)
+
+// viewport is where the virtual screen lands in the window this frame.
+var viewport rl.Rectangle
+
+// Viewport returns the window rectangle the virtual screen is drawn into.
+func Viewport() rl.Rectangle { return viewport }
+
+// ScreenToVirtual converts window pixel coordinates to virtual ones.
+func ScreenToVirtual(x, y float32) (float32, float32) {
+	if viewport.Width <= 0 || viewport.Height <= 0 {
+		return x, y
+	}
+	return (x - viewport.X) * float32(Width) / viewport.Width,
+		(y - viewport.Y) * float32(Height) / viewport.Height
+}
+
+// VirtualToScreen converts virtual coordinates to window pixels.
+func VirtualToScreen(x, y float32) (float32, float32) {
+	if Width == 0 || Height == 0 {
+		return x, y
+	}
+	return viewport.X + x*viewport.Width/float32(Width),
+		viewport.Y + y*viewport.Height/float32(Height)
+}
+
+// fitViewport computes the letterboxed rectangle for a vw×vh virtual
+// screen inside a sw×sh window.
+func fitViewport(mode ScaleMode, vw, vh, sw, sh int) rl.Rectangle {
+	if mode == ScaleNone || vw <= 0 || vh <= 0 {
+		return rl.NewRectangle(0, 0, float32(sw), float32(sh))
+	}
+	k := math.Min(float64(sw)/float64(vw), float64(sh)/float64(vh))
+	if mode == ScaleInteger && k >= 1 {
+		k = math.Floor(k)
+	}
+	w := math.Floor(float64(vw) * k)
+	h := math.Floor(float64(vh) * k)
+	x := math.Floor((float64(sw) - w) / 2)
+	y := math.Floor((float64(sh) - h) / 2)
+	return rl.NewRectangle(float32(x), float32(y), float32(w), float32(h))
+}
+
+// SetVirtualResolution renders the game at w×h and scales it to the window
+// with the given mode.  The window keeps the size from WithSize (or
+// SetWindowSize) and becomes resizable.  Width, Height, HalfWidth,
+// HalfHeight and the mouse position are all in virtual units.
+func (e *Engine) SetVirtualResolution(w, h int, mode ScaleMode) {
+	e.width, e.height = w, h
+	e.scaleMode = mode
+	if rl.IsWindowReady() {
+		Resize(e.virtualSize())
+		if mode != ScaleNone {
+			rl.SetWindowState(rl.FlagWindowResizable)
+		}
+	}
+}
+
+// SetWindowSize sets the window size in pixels.  Without a virtual
+// resolution it also changes Width/Height.
+func (e *Engine) SetWindowSize(w, h int) {
+	e.winW, e.winH = w, h
+	if rl.IsWindowReady() {
+		Resize(e.virtualSize())
+		rl.SetWindowSize(w, h)
+	}
+}
+
+// SetLetterboxColor sets the color of the bars around a scaled screen.
+func (e *Engine) SetLetterboxColor(c Color) {
+	e.letterbox = c
+}
+
+// beginVirtual updates the viewport and mouse mapping for this frame and,
+// when scaling, redirects drawing into the virtual target.  Returns whether
+// it did.
+func (e *Engine) beginVirtual(post bool) bool {
+	sw, sh := rl.GetScreenWidth(), rl.GetScreenHeight()
+	viewport = fitViewport(e.scaleMode, Width, Height, sw, sh)
+	if e.scaleMode == ScaleNone {
+		if e.mouseMapped {
+			rl.SetMouseOffset(0, 0)
+			rl.SetMouseScale(1, 1)
+			e.mouseMapped = false
+		}
+		return false
+	}
+	rl.SetMouseOffset(-int32(viewport.X), -int32(viewport.Y))
+	rl.SetMouseScale(float32(Width)/viewport.Width, float32(Height)/viewport.Height)
+	e.mouseMapped = true
+	if post {
+		return false // PostFX renders at Width×Height itself
+	}
+
+	if e.targetW != Width || e.targetH != Height {
+		if e.targetW > 0 {
+			rl.UnloadRenderTexture(e.target)
+		}
+		e.target = rl.LoadRenderTexture(int32(Width), int32(Height))
+		rl.SetTextureFilter(e.target.Texture, rl.FilterPoint)
+		e.targetW, e.targetH = Width, Height
+	}
+	rl.BeginTextureMode(e.target)
+	return true
+}
+
+// endVirtual draws the virtual target into the letterboxed viewport.
+func (e *Engine) endVirtual() {
+	rl.EndTextureMode()
+	rl.ClearBackground(e.letterbox)
+	src := rl.NewRectangle(0, 0, float32(e.targetW), -float32(e.targetH))
+	rl.DrawTexturePro(e.target.Texture, src, viewport, rl.Vector2{}, 0, rl.White)
+}
+
+// unloadVirtual frees the virtual target.
+func (e *Engine) unloadVirtual() {
+	if e.targetW > 0 {
+		rl.UnloadRenderTexture(e.target)
+		e.targetW, e.targetH = 0, 0
+	}
+}
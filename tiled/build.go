@@ -0,0 +1,162 @@
+package tiled
+
+import (
+	"fmt"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
+	"github.com/henrypekny/runt/loader"
+	"github.com/henrypekny/runt/mask"
+)
+
+// Tilemap builds a graphics.Tilemap with one layer per tile layer of the
+// map.  Tile indices are Tiled GIDs minus one, so index i of the result
+// corresponds to GID i+1 in every tileset.  Layer opacity becomes the layer
+// tint's alpha, layer offsets become TileLayer offsets and animated tiles
+// are registered with the tilemap.  Image collection tilesets (used by tile
+// objects) are skipped; a tile layer drawing from one is an error.
+func (m *Map) Tilemap() (*graphics.Tilemap, error) {
+	tm := graphics.NewTilemapSize(m.TileWidth, m.TileHeight, m.Width, m.Height)
+	tm.X, tm.Y = m.OffsetX, m.OffsetY
+
+	collections := false
+	for _, ts := range m.Tilesets {
+		if ts.Image == "" {
+			collections = true
+			continue
+		}
+		tex := loader.LoadTexture(ts.Image)
+		gts := graphics.NewTileset(tex, ts.TileWidth, ts.TileHeight, ts.Margin, ts.Spacing)
+		if ts.Columns > 0 {
+			gts.Columns = ts.Columns
+		}
+		if ts.TileCount > 0 {
+			gts.Count = ts.TileCount
+		}
+		first := tm.AddTilesetAt(gts, ts.FirstGID-1)
+
+		for id, info := range ts.Tiles {
+			if len(info.Animation) == 0 {
+				continue
+			}
+			frames := make([]int, len(info.Animation))
+			durations := make([]float64, len(info.Animation))
+			for i, f := range info.Animation {
+				frames[i] = first + f.TileID
+				durations[i] = f.Duration
+			}
+			if err := tm.SetTileAnimation(first+id, frames, durations); err != nil {
+				return nil, fmt.Errorf("tiled: tileset %q: %w", ts.Name, err)
+			}
+		}
+	}
+
+	for _, l := range m.Layers {
+		if l.Kind != TileLayer {
+			continue
+		}
+		tl := tm.AddLayer(l.Name)
+		tl.Visible = l.Visible
+		tl.Color = rl.NewColor(255, 255, 255, uint8(l.Opacity*255))
+		tl.OffsetX, tl.OffsetY = float32(l.OffsetX), float32(l.OffsetY)
+		for row := 0; row < l.Height; row++ {
+			for col := 0; col < l.Width; col++ {
+				gid := l.Tiles[row*l.Width+col]
+				if gid&gidMask == 0 {
+					continue
+				}
+				if collections {
+					if ts := m.TilesetFor(gid); ts != nil && ts.Image == "" {
+						return nil, fmt.Errorf("tiled: layer %q uses image collection tileset %q", l.Name, ts.Name)
+					}
+				}
+				tl.SetTileFlags(col, row, int(gid&gidMask)-1, tileFlags(gid))
+			}
+		}
+	}
+	return tm, nil
+}
+
+// tileFlags converts GID flip bits to graphics.TileFlags.
+func tileFlags(gid uint32) graphics.TileFlags {
+	var f graphics.TileFlags
+	if gid&flagFlipX != 0 {
+		f |= graphics.FlipX
+	}
+	if gid&flagFlipY != 0 {
+		f |= graphics.FlipY
+	}
+	if gid&flagFlipDiag != 0 {
+		f |= graphics.FlipDiagonal
+	}
+	return f
+}
+
+// Grid builds a collision grid from the named tile layer.  solid receives
+// the tile index (GID minus one, as in Tilemap) of every non-empty cell; a
+// nil solid treats every non-empty cell as solid.
+func (m *Map) Grid(layer string, solid func(index int) bool) (*mask.Grid, error) {
+	l := m.LayerByName(layer)
+	if l == nil || l.Kind != TileLayer {
+		return nil, fmt.Errorf("tiled: no tile layer %q", layer)
+	}
+	isSolid := func(index int) bool {
+		if index < 0 {
+			return false
+		}
+		return solid == nil || solid(index)
+	}
+	g := mask.NewGridFromTiles(layerTiles{l}, float32(m.TileWidth), float32(m.TileHeight), isSolid)
+	g.XOff, g.YOff = m.OffsetX+float32(l.OffsetX), m.OffsetY+float32(l.OffsetY)
+	return g, nil
+}
+
+// SolidProperty returns a Grid solid func that checks a bool tile property,
+// e.g. m.Grid("walls", m.SolidProperty("solid")).
+func (m *Map) SolidProperty(name string) func(index int) bool {
+	return func(index int) bool {
+		info := m.TileInfo(uint32(index + 1))
+		return info != nil && info.Properties.Bool(name, false)
+	}
+}
+
+// layerTiles adapts a Layer to mask.TileSource.
+type layerTiles struct{ l *Layer }
+
+func (t layerTiles) Columns() int { return t.l.Width }
+func (t layerTiles) Rows() int    { return t.l.Height }
+func (t layerTiles) Tile(col, row int) int {
+	return int(t.l.Tiles[row*t.l.Width+col]&gidMask) - 1
+}
+
+// SpawnObjects walks every object layer in order and calls the spawner
+// registered for each object's Type.  The "" entry, if present, receives
+// objects whose type has no spawner of its own.
+func (m *Map) SpawnObjects(spawners map[string]func(obj *Object)) {
+	fallback := spawners[""]
+	for _, l := range m.Layers {
+		if l.Kind != ObjectLayer {
+			continue
+		}
+		for _, o := range l.Objects {
+			if fn, ok := spawners[o.Type]; ok && o.Type != "" {
+				fn(o)
+			} else if fallback != nil {
+				fallback(o)
+			}
+		}
+	}
+}
+
+// Objects returns every object of the given type across all object layers.
+func (m *Map) Objects(typ string) []*Object {
+	var out []*Object
+	for _, l := range m.Layers {
+		for _, o := range l.Objects {
+			if o.Type == typ {
+				out = append(out, o)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package tiled
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// chunk is a rectangle of raw GIDs.  Finite maps have a single chunk at
+// (0,0) covering the whole layer; infinite maps have many.
+type chunk struct {
+	x, y, w, h int
+	gids       []uint32
+}
+
+// decodeCSV parses comma separated GIDs.
+func decodeCSV(s string) ([]uint32, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r' || r == ' ' || r == '\t'
+	})
+	out := make([]uint32, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseUint(f, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("bad csv tile %q", f)
+		}
+		out[i] = uint32(v)
+	}
+	return out, nil
+}
+
+// decodeBase64 parses base64 GIDs, optionally zlib, gzip or zstd compressed.
+func decodeBase64(s, compression string) ([]uint32, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader
+	switch compression {
+	case "":
+		r = bytes.NewReader(raw)
+	case "zlib":
+		zr, err := zlib.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	case "gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	case "zstd":
+		zr, err := zstd.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf)%4 != 0 {
+		return nil, fmt.Errorf("tile data length %d is not a multiple of 4", len(buf))
+	}
+	out := make([]uint32, len(buf)/4)
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint32(buf[i*4:])
+	}
+	return out, nil
+}
+
+// decodeData dispatches on Tiled's encoding attribute.
+func decodeData(s, encoding, compression string) ([]uint32, error) {
+	switch encoding {
+	case "csv":
+		return decodeCSV(s)
+	case "base64":
+		return decodeBase64(s, compression)
+	}
+	return nil, fmt.Errorf("unsupported encoding %q", encoding)
+}
+
+// composeChunks turns the raw chunks of every tile layer into a single
+// Width×Height grid.  For infinite maps the grid covers the union of all
+// chunks and OffsetX/OffsetY record where cell (0,0) ended up.
+func (m *Map) composeChunks() error {
+	minX, minY, maxX, maxY := 0, 0, m.Width, m.Height
+	if m.Infinite {
+		first := true
+		for _, l := range m.Layers {
+			for _, c := range l.chunks {
+				if first {
+					minX, minY, maxX, maxY = c.x, c.y, c.x+c.w, c.y+c.h
+					first = false
+					continue
+				}
+				minX, minY = min(minX, c.x), min(minY, c.y)
+				maxX, maxY = max(maxX, c.x+c.w), max(maxY, c.y+c.h)
+			}
+		}
+		m.Width, m.Height = maxX-minX, maxY-minY
+		m.OffsetX = float32(minX * m.TileWidth)
+		m.OffsetY = float32(minY * m.TileHeight)
+	}
+
+	for _, l := range m.Layers {
+		if l.Kind != TileLayer {
+			continue
+		}
+		l.Width, l.Height = m.Width, m.Height
+		l.Tiles = make([]uint32, m.Width*m.Height)
+		for _, c := range l.chunks {
+			if len(c.gids) < c.w*c.h {
+				return fmt.Errorf("layer %q: expected %d tiles, got %d", l.Name, c.w*c.h, len(c.gids))
+			}
+			for row := 0; row < c.h; row++ {
+				y := c.y + row - minY
+				if y < 0 || y >= m.Height {
+					continue
+				}
+				for col := 0; col < c.w; col++ {
+					x := c.x + col - minX
+					if x < 0 || x >= m.Width {
+						continue
+					}
+					l.Tiles[y*m.Width+x] = c.gids[row*c.w+col]
+				}
+			}
+		}
+		l.chunks = nil
+	}
+	return nil
+}
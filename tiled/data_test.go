@@ -0,0 +1,161 @@
+// runt/tiled/data_test.go
+package tiled
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/henrypekny/runt/graphics"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	got, err := decodeCSV("1,2,\n3, 0,\r\n\t2147483653")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint32{1, 2, 3, 0, 2147483653}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, err := decodeCSV("1,x,3"); err == nil {
+		t.Error("bad tile decoded without error")
+	}
+}
+
+// encodeGIDs packs gids the way Tiled does, compressed with compression.
+func encodeGIDs(t *testing.T, gids []uint32, compression string) string {
+	t.Helper()
+	raw := make([]byte, 4*len(gids))
+	for i, g := range gids {
+		binary.LittleEndian.PutUint32(raw[i*4:], g)
+	}
+	var buf bytes.Buffer
+	switch compression {
+	case "":
+		buf.Write(raw)
+	case "zlib":
+		w := zlib.NewWriter(&buf)
+		w.Write(raw)
+		w.Close()
+	case "gzip":
+		w := gzip.NewWriter(&buf)
+		w.Write(raw)
+		w.Close()
+	case "zstd":
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf.Write(enc.EncodeAll(raw, nil))
+		enc.Close()
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecodeBase64(t *testing.T) {
+	gids := []uint32{0, 1, 7, flagFlipX | 3, 0xFFFF}
+	for _, c := range []string{"", "zlib", "gzip", "zstd"} {
+		got, err := decodeData("\n  "+encodeGIDs(t, gids, c)+"\n", "base64", c)
+		if err != nil {
+			t.Errorf("%q: %v", c, err)
+			continue
+		}
+		if !slices.Equal(got, gids) {
+			t.Errorf("%q: got %v, want %v", c, got, gids)
+		}
+	}
+}
+
+func TestDecodeBase64Errors(t *testing.T) {
+	if _, err := decodeBase64(base64.StdEncoding.EncodeToString([]byte{1, 2, 3}), ""); err == nil {
+		t.Error("data not a multiple of 4 bytes decoded without error")
+	}
+	if _, err := decodeBase64(encodeGIDs(t, []uint32{1}, ""), "lz4"); err == nil {
+		t.Error("unknown compression decoded without error")
+	}
+	if _, err := decodeData("1", "xml", ""); err == nil {
+		t.Error("unknown encoding decoded without error")
+	}
+}
+
+func TestGIDFlipBits(t *testing.T) {
+	gid := flagFlipX | flagFlipDiag | 42
+	if id := gid & gidMask; id != 42 {
+		t.Errorf("gid %#x masks to %d, want 42", gid, id)
+	}
+	if f := tileFlags(gid); f != graphics.FlipX|graphics.FlipDiagonal {
+		t.Errorf("flags %v, want FlipX|FlipDiagonal", f)
+	}
+	if f := tileFlags(flagFlipY | flagRotateHex | 1); f != graphics.FlipY {
+		t.Errorf("flags %v, want FlipY", f)
+	}
+}
+
+const infiniteTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map orientation="orthogonal" width="4" height="2" tilewidth="16" tileheight="16" infinite="1">
+ <layer name="ground" width="4" height="2">
+  <data encoding="csv">
+   <chunk x="-2" y="-1" width="2" height="1">1,2</chunk>
+   <chunk x="0" y="0" width="2" height="1">3,4</chunk>
+  </data>
+ </layer>
+</map>`
+
+func TestInfiniteChunks(t *testing.T) {
+	m, err := parseTMX([]byte(infiniteTMX), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.composeChunks(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Width != 4 || m.Height != 2 {
+		t.Fatalf("size %d×%d, want 4×2", m.Width, m.Height)
+	}
+	if m.OffsetX != -32 || m.OffsetY != -16 {
+		t.Errorf("offset (%g,%g), want (-32,-16)", m.OffsetX, m.OffsetY)
+	}
+	want := []uint32{
+		1, 2, 0, 0,
+		0, 0, 3, 4,
+	}
+	if got := m.Layers[0].Tiles; !slices.Equal(got, want) {
+		t.Errorf("tiles %v, want %v", got, want)
+	}
+}
+
+const offsetsTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map orientation="orthogonal" width="2" height="1" tilewidth="16" tileheight="16" infinite="0">
+ <tileset firstgid="1" name="props" tilewidth="16" tileheight="16" tilecount="1" columns="0">
+  <tile id="0"><image source="lamp.png" width="16" height="32"/></tile>
+ </tileset>
+ <layer name="back" width="2" height="1"><data encoding="csv">0,0</data></layer>
+ <group name="deco" offsetx="10" offsety="-4">
+  <layer name="vines" width="2" height="1" offsetx="5"><data encoding="csv">0,0</data></layer>
+ </group>
+</map>`
+
+func TestLayerOffsetsAndImageCollections(t *testing.T) {
+	m, err := parseTMX([]byte(offsetsTMX), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tilesets) != 1 || m.Tilesets[0].Image != "" {
+		t.Fatalf("image collection tileset not kept: %+v", m.Tilesets)
+	}
+	back, vines := m.LayerByName("back"), m.LayerByName("vines")
+	if back == nil || vines == nil {
+		t.Fatal("layers missing")
+	}
+	if back.OffsetX != 0 || back.OffsetY != 0 {
+		t.Errorf("back offset (%g,%g), want (0,0)", back.OffsetX, back.OffsetY)
+	}
+	if vines.OffsetX != 15 || vines.OffsetY != -4 {
+		t.Errorf("vines offset (%g,%g), want (15,-4)", vines.OffsetX, vines.OffsetY)
+	}
+}
@@ -0,0 +1,304 @@
+package tiled
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// -----------------------------------------------------------------------------
+// Tiled JSON (TMJ / TSJ) documents
+// -----------------------------------------------------------------------------
+
+type jsonMap struct {
+	Orientation     string         `json:"orientation"`
+	Infinite        bool           `json:"infinite"`
+	Width           int            `json:"width"`
+	Height          int            `json:"height"`
+	TileWidth       int            `json:"tilewidth"`
+	TileHeight      int            `json:"tileheight"`
+	BackgroundColor string         `json:"backgroundcolor"`
+	Properties      jsonProperties `json:"properties"`
+	Tilesets        []jsonTileset  `json:"tilesets"`
+	Layers          []jsonLayer    `json:"layers"`
+}
+
+type jsonProperties []struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+type jsonTileset struct {
+	FirstGID    int            `json:"firstgid"`
+	Source      string         `json:"source"`
+	Name        string         `json:"name"`
+	TileWidth   int            `json:"tilewidth"`
+	TileHeight  int            `json:"tileheight"`
+	Spacing     int            `json:"spacing"`
+	Margin      int            `json:"margin"`
+	TileCount   int            `json:"tilecount"`
+	Columns     int            `json:"columns"`
+	Image       string         `json:"image"`
+	ImageWidth  int            `json:"imagewidth"`
+	ImageHeight int            `json:"imageheight"`
+	Properties  jsonProperties `json:"properties"`
+	Tiles       []struct {
+		ID         int            `json:"id"`
+		Type       string         `json:"type"`
+		Class      string         `json:"class"`
+		Properties jsonProperties `json:"properties"`
+		Animation  []struct {
+			TileID   int `json:"tileid"`
+			Duration int `json:"duration"` // ms
+		} `json:"animation"`
+	} `json:"tiles"`
+}
+
+type jsonLayer struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Visible     *bool           `json:"visible"`
+	Opacity     *float64        `json:"opacity"`
+	OffsetX     float64         `json:"offsetx"`
+	OffsetY     float64         `json:"offsety"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Properties  jsonProperties  `json:"properties"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Data        json.RawMessage `json:"data"` // []uint32 or base64 string
+	Chunks      []struct {
+		X      int             `json:"x"`
+		Y      int             `json:"y"`
+		Width  int             `json:"width"`
+		Height int             `json:"height"`
+		Data   json.RawMessage `json:"data"`
+	} `json:"chunks"`
+	Objects []jsonObject `json:"objects"`
+	Image   string       `json:"image"`
+	Layers  []jsonLayer  `json:"layers"` // group children
+}
+
+type jsonObject struct {
+	ID         int            `json:"id"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Class      string         `json:"class"`
+	X          float64        `json:"x"`
+	Y          float64        `json:"y"`
+	Width      float64        `json:"width"`
+	Height     float64        `json:"height"`
+	Rotation   float64        `json:"rotation"`
+	GID        uint32         `json:"gid"`
+	Visible    *bool          `json:"visible"`
+	Point      bool           `json:"point"`
+	Ellipse    bool           `json:"ellipse"`
+	Polygon    []Point        `json:"polygon"`
+	Polyline   []Point        `json:"polyline"`
+	Properties jsonProperties `json:"properties"`
+}
+
+// parseJSON decodes a Tiled JSON map; dir is the directory of the map file.
+func parseJSON(data []byte, dir string) (*Map, error) {
+	var jm jsonMap
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return nil, err
+	}
+	m := &Map{
+		Orientation: jm.Orientation,
+		Infinite:    jm.Infinite,
+		Width:       jm.Width,
+		Height:      jm.Height,
+		TileWidth:   jm.TileWidth,
+		TileHeight:  jm.TileHeight,
+		Properties:  jm.Properties.toProperties(),
+	}
+	m.BackgroundColor, _ = parseColor(jm.BackgroundColor)
+
+	for _, jt := range jm.Tilesets {
+		var ts *Tileset
+		var err error
+		if jt.Source != "" {
+			ts, err = loadExternalTileset(jt.Source, dir, jt.FirstGID)
+		} else {
+			ts, err = jt.toTileset(dir)
+			if ts != nil {
+				ts.FirstGID = jt.FirstGID
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+
+	for _, jl := range jm.Layers {
+		if err := m.addJSONLayer(jl, dir, 0, 0, 1, true); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// parseTSJ decodes an external JSON tileset.
+func parseTSJ(data []byte, dir string) (*Tileset, error) {
+	var jt jsonTileset
+	if err := json.Unmarshal(data, &jt); err != nil {
+		return nil, err
+	}
+	return jt.toTileset(dir)
+}
+
+func (jt *jsonTileset) toTileset(dir string) (*Tileset, error) {
+	ts := &Tileset{
+		Name:        jt.Name,
+		TileWidth:   jt.TileWidth,
+		TileHeight:  jt.TileHeight,
+		Spacing:     jt.Spacing,
+		Margin:      jt.Margin,
+		TileCount:   jt.TileCount,
+		Columns:     jt.Columns,
+		Image:       resolveRelative(jt.Image, dir),
+		ImageWidth:  jt.ImageWidth,
+		ImageHeight: jt.ImageHeight,
+		Properties:  jt.Properties.toProperties(),
+		Tiles:       make(map[int]*TileInfo),
+	}
+	for _, t := range jt.Tiles {
+		info := &TileInfo{
+			ID:         t.ID,
+			Type:       firstNonEmpty(t.Class, t.Type),
+			Properties: t.Properties.toProperties(),
+		}
+		for _, f := range t.Animation {
+			info.Animation = append(info.Animation, Frame{
+				TileID:   f.TileID,
+				Duration: float64(f.Duration) / 1000,
+			})
+		}
+		ts.Tiles[t.ID] = info
+	}
+	return ts, nil
+}
+
+// addJSONLayer mirrors addXMLLayer for the JSON format.
+func (m *Map) addJSONLayer(jl jsonLayer, dir string, ox, oy, opacity float64, visible bool) error {
+	if jl.Visible != nil && !*jl.Visible {
+		visible = false
+	}
+	if jl.Opacity != nil {
+		opacity *= *jl.Opacity
+	}
+	ox += jl.OffsetX
+	oy += jl.OffsetY
+
+	l := &Layer{
+		Name:       jl.Name,
+		Visible:    visible,
+		Opacity:    opacity,
+		OffsetX:    ox,
+		OffsetY:    oy,
+		Properties: jl.Properties.toProperties(),
+	}
+
+	switch jl.Type {
+	case "group":
+		for _, child := range jl.Layers {
+			if err := m.addJSONLayer(child, dir, ox, oy, opacity, visible); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "tilelayer":
+		l.Kind = TileLayer
+		if len(jl.Chunks) > 0 {
+			for _, jc := range jl.Chunks {
+				gids, err := decodeJSONData(jc.Data, jl.Encoding, jl.Compression)
+				if err != nil {
+					return fmt.Errorf("layer %q: %w", jl.Name, err)
+				}
+				l.chunks = append(l.chunks, chunk{jc.X, jc.Y, jc.Width, jc.Height, gids})
+			}
+			break
+		}
+		gids, err := decodeJSONData(jl.Data, jl.Encoding, jl.Compression)
+		if err != nil {
+			return fmt.Errorf("layer %q: %w", jl.Name, err)
+		}
+		l.chunks = []chunk{{0, 0, jl.Width, jl.Height, gids}}
+
+	case "objectgroup":
+		l.Kind = ObjectLayer
+		for _, jo := range jl.Objects {
+			o := &Object{
+				ID:         jo.ID,
+				Name:       jo.Name,
+				Type:       firstNonEmpty(jo.Class, jo.Type),
+				X:          jo.X,
+				Y:          jo.Y,
+				Width:      jo.Width,
+				Height:     jo.Height,
+				Rotation:   jo.Rotation,
+				GID:        jo.GID,
+				Visible:    jo.Visible == nil || *jo.Visible,
+				Point:      jo.Point,
+				Ellipse:    jo.Ellipse,
+				Polygon:    jo.Polygon,
+				Polyline:   jo.Polyline,
+				Properties: jo.Properties.toProperties(),
+				Layer:      l,
+			}
+			m.inheritTileType(o)
+			l.Objects = append(l.Objects, o)
+		}
+
+	case "imagelayer":
+		l.Kind = ImageLayer
+		l.Image = resolveRelative(jl.Image, dir)
+
+	default:
+		return nil
+	}
+
+	m.Layers = append(m.Layers, l)
+	return nil
+}
+
+// decodeJSONData handles both array and base64 string tile data.
+func decodeJSONData(raw json.RawMessage, encoding, compression string) ([]uint32, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if encoding == "base64" {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return decodeBase64(s, compression)
+	}
+	var gids []uint32
+	if err := json.Unmarshal(raw, &gids); err != nil {
+		return nil, err
+	}
+	return gids, nil
+}
+
+func (jp jsonProperties) toProperties() Properties {
+	props := make(Properties, len(jp))
+	for _, p := range jp {
+		var s string
+		if err := json.Unmarshal(p.Value, &s); err == nil {
+			props[p.Name] = s
+			continue
+		}
+		var f float64
+		if err := json.Unmarshal(p.Value, &f); err == nil {
+			props[p.Name] = strconv.FormatFloat(f, 'f', -1, 64)
+			continue
+		}
+		// bools and class values keep their JSON text
+		props[p.Name] = string(p.Value)
+	}
+	return props
+}
@@ -0,0 +1,289 @@
+// Package tiled loads maps made with the Tiled editor (TMX/TSX and Tiled
+// JSON/TMJ/TSJ) and turns them into runt tilemaps, collision grids and
+// entity spawn callbacks.
+package tiled
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// Tile GIDs carry flip flags in their top bits.
+const (
+	flagFlipX     uint32 = 0x80000000
+	flagFlipY     uint32 = 0x40000000
+	flagFlipDiag  uint32 = 0x20000000
+	flagRotateHex uint32 = 0x10000000
+	gidMask              = ^(flagFlipX | flagFlipY | flagFlipDiag | flagRotateHex)
+)
+
+// Map is a parsed Tiled map.  Groups are flattened: Layers holds every tile,
+// object and image layer in draw order with group offsets, visibility and
+// opacity already folded in.
+type Map struct {
+	Orientation string
+	Infinite    bool
+
+	Width, Height         int // size in tiles
+	TileWidth, TileHeight int // grid cell size in pixels
+
+	// OffsetX/OffsetY is the pixel position of cell (0,0).  It is only
+	// non-zero for infinite maps whose content starts at negative cells.
+	OffsetX, OffsetY float32
+
+	BackgroundColor rl.Color
+	Properties      Properties
+
+	Tilesets []*Tileset
+	Layers   []*Layer
+}
+
+// Tileset is one tileset referenced by a map.
+type Tileset struct {
+	FirstGID int
+	Name     string
+
+	TileWidth, TileHeight int
+	Spacing, Margin       int
+	TileCount, Columns    int
+
+	Image                   string // resolved path of the sheet; "" for an image collection
+	ImageWidth, ImageHeight int
+
+	Properties Properties
+	Tiles      map[int]*TileInfo // per-tile data by local id
+}
+
+// TileInfo holds the optional data Tiled stores for a single tile.
+type TileInfo struct {
+	ID         int
+	Type       string
+	Properties Properties
+	Animation  []Frame
+}
+
+// Frame is one step of an animated tile.
+type Frame struct {
+	TileID   int     // local id in the same tileset
+	Duration float64 // seconds
+}
+
+// LayerKind tells tile, object and image layers apart.
+type LayerKind int
+
+const (
+	TileLayer LayerKind = iota
+	ObjectLayer
+	ImageLayer
+)
+
+// Layer is a flattened map layer.
+type Layer struct {
+	Name    string
+	Kind    LayerKind
+	Visible bool
+	Opacity float64
+
+	OffsetX, OffsetY float64
+	Properties       Properties
+
+	// tile layers: Width×Height raw GIDs, row-major, flip flags included
+	Width, Height int
+	Tiles         []uint32
+
+	// object layers
+	Objects []*Object
+
+	// image layers
+	Image string
+
+	// raw chunk data, composed into Tiles once the map bounds are known
+	chunks []chunk
+}
+
+// Object is a single object from an object layer.  Tile objects (GID != 0)
+// are positioned by their bottom-left corner, as in Tiled.
+type Object struct {
+	ID   int
+	Name string
+	Type string // "type" or, since Tiled 1.9, "class"
+
+	X, Y, Width, Height float64
+	Rotation            float64
+	GID                 uint32
+	Visible             bool
+
+	Point, Ellipse bool
+	Polygon        []Point
+	Polyline       []Point
+
+	Properties Properties
+	Layer      *Layer
+}
+
+// Point is a polygon/polyline vertex relative to its object.
+type Point struct{ X, Y float64 }
+
+// Properties are custom properties as strings, whatever their Tiled type.
+type Properties map[string]string
+
+// Get returns the property or def if it is missing.
+func (p Properties) Get(name, def string) string {
+	if v, ok := p[name]; ok {
+		return v
+	}
+	return def
+}
+
+// Int returns the property as an int or def.
+func (p Properties) Int(name string, def int) int {
+	if v, err := strconv.Atoi(p[name]); err == nil {
+		return v
+	}
+	return def
+}
+
+// Float returns the property as a float64 or def.
+func (p Properties) Float(name string, def float64) float64 {
+	if v, err := strconv.ParseFloat(p[name], 64); err == nil {
+		return v
+	}
+	return def
+}
+
+// Bool returns the property as a bool or def.
+func (p Properties) Bool(name string, def bool) bool {
+	if v, err := strconv.ParseBool(p[name]); err == nil {
+		return v
+	}
+	return def
+}
+
+// Color returns a "#RRGGBB" or "#AARRGGBB" property or def.
+func (p Properties) Color(name string, def rl.Color) rl.Color {
+	if c, ok := parseColor(p[name]); ok {
+		return c
+	}
+	return def
+}
+
+// Load reads a .tmx or .json/.tmj map through loader.Resolve.  External
+// tilesets and images are resolved relative to the file that references them.
+func Load(path string) (*Map, error) {
+	full, err := loader.Resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := loader.ReadFile(full)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(full)
+
+	var m *Map
+	switch strings.ToLower(filepath.Ext(full)) {
+	case ".tmx", ".xml":
+		m, err = parseTMX(data, dir)
+	case ".json", ".tmj":
+		m, err = parseJSON(data, dir)
+	default:
+		return nil, fmt.Errorf("tiled: unknown map format %q", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tiled: %s: %w", path, err)
+	}
+	if err := m.composeChunks(); err != nil {
+		return nil, fmt.Errorf("tiled: %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// loadExternalTileset reads a .tsx or .json/.tsj tileset file referenced
+// from dir and returns it with firstGID applied.
+func loadExternalTileset(source, dir string, firstGID int) (*Tileset, error) {
+	path := resolveRelative(source, dir)
+	data, err := loader.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tsDir := filepath.Dir(path)
+	if full, err := loader.Resolve(path); err == nil {
+		tsDir = filepath.Dir(full)
+	}
+
+	var ts *Tileset
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsx", ".xml":
+		ts, err = parseTSX(data, tsDir)
+	case ".json", ".tsj":
+		ts, err = parseTSJ(data, tsDir)
+	default:
+		return nil, fmt.Errorf("unknown tileset format %q", source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tileset %s: %w", source, err)
+	}
+	ts.FirstGID = firstGID
+	return ts, nil
+}
+
+// resolveRelative joins a path found inside a map file with the directory
+// of that file, leaving absolute paths alone.
+func resolveRelative(path, dir string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
+
+// parseColor parses Tiled's "#RRGGBB" / "#AARRGGBB" colors.
+func parseColor(s string) (rl.Color, bool) {
+	s = strings.TrimPrefix(s, "#")
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return rl.Color{}, false
+	}
+	switch len(s) {
+	case 6:
+		return rl.NewColor(uint8(v>>16), uint8(v>>8), uint8(v), 0xFF), true
+	case 8:
+		return rl.NewColor(uint8(v>>16), uint8(v>>8), uint8(v), uint8(v>>24)), true
+	}
+	return rl.Color{}, false
+}
+
+// TilesetFor returns the tileset that owns gid (flip flags are ignored).
+func (m *Map) TilesetFor(gid uint32) *Tileset {
+	id := int(gid & gidMask)
+	var best *Tileset
+	for _, ts := range m.Tilesets {
+		if ts.FirstGID <= id && (best == nil || ts.FirstGID > best.FirstGID) {
+			best = ts
+		}
+	}
+	return best
+}
+
+// TileInfo returns the per-tile data for gid, or nil.
+func (m *Map) TileInfo(gid uint32) *TileInfo {
+	ts := m.TilesetFor(gid)
+	if ts == nil {
+		return nil
+	}
+	return ts.Tiles[int(gid&gidMask)-ts.FirstGID]
+}
+
+// LayerByName returns the first layer with the given name, or nil.
+func (m *Map) LayerByName(name string) *Layer {
+	for _, l := range m.Layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package tiled
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// TMX / TSX (XML) documents
+// -----------------------------------------------------------------------------
+
+type xmlMap struct {
+	Orientation     string        `xml:"orientation,attr"`
+	Infinite        int           `xml:"infinite,attr"`
+	Width           int           `xml:"width,attr"`
+	Height          int           `xml:"height,attr"`
+	TileWidth       int           `xml:"tilewidth,attr"`
+	TileHeight      int           `xml:"tileheight,attr"`
+	BackgroundColor string        `xml:"backgroundcolor,attr"`
+	Properties      xmlProperties `xml:"properties"`
+	Tilesets        []xmlTileset  `xml:"tileset"`
+	Layers          []xmlLayer    `xml:",any"` // layer, objectgroup, imagelayer, group
+}
+
+type xmlProperties struct {
+	Property []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+		Text  string `xml:",chardata"` // multi-line strings
+	} `xml:"property"`
+}
+
+type xmlTileset struct {
+	FirstGID   int           `xml:"firstgid,attr"`
+	Source     string        `xml:"source,attr"`
+	Name       string        `xml:"name,attr"`
+	TileWidth  int           `xml:"tilewidth,attr"`
+	TileHeight int           `xml:"tileheight,attr"`
+	Spacing    int           `xml:"spacing,attr"`
+	Margin     int           `xml:"margin,attr"`
+	TileCount  int           `xml:"tilecount,attr"`
+	Columns    int           `xml:"columns,attr"`
+	Image      *xmlImage     `xml:"image"`
+	Properties xmlProperties `xml:"properties"`
+	Tiles      []struct {
+		ID         int           `xml:"id,attr"`
+		Type       string        `xml:"type,attr"`
+		Class      string        `xml:"class,attr"`
+		Properties xmlProperties `xml:"properties"`
+		Frames     []struct {
+			TileID   int `xml:"tileid,attr"`
+			Duration int `xml:"duration,attr"` // ms
+		} `xml:"animation>frame"`
+	} `xml:"tile"`
+}
+
+type xmlImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+}
+
+type xmlLayer struct {
+	XMLName    xml.Name
+	Name       string        `xml:"name,attr"`
+	Visible    *int          `xml:"visible,attr"`
+	Opacity    *float64      `xml:"opacity,attr"`
+	OffsetX    float64       `xml:"offsetx,attr"`
+	OffsetY    float64       `xml:"offsety,attr"`
+	Width      int           `xml:"width,attr"`
+	Height     int           `xml:"height,attr"`
+	Properties xmlProperties `xml:"properties"`
+	Data       *struct {
+		Encoding    string `xml:"encoding,attr"`
+		Compression string `xml:"compression,attr"`
+		Text        string `xml:",chardata"`
+		Tiles       []struct {
+			GID uint32 `xml:"gid,attr"`
+		} `xml:"tile"` // legacy unencoded XML data
+		Chunks []struct {
+			X      int    `xml:"x,attr"`
+			Y      int    `xml:"y,attr"`
+			Width  int    `xml:"width,attr"`
+			Height int    `xml:"height,attr"`
+			Text   string `xml:",chardata"`
+		} `xml:"chunk"`
+	} `xml:"data"`
+	Objects  []xmlObject `xml:"object"`
+	Image    *xmlImage   `xml:"image"`
+	Children []xmlLayer  `xml:",any"` // nested layers of a group
+}
+
+type xmlObject struct {
+	ID         int           `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	X          float64       `xml:"x,attr"`
+	Y          float64       `xml:"y,attr"`
+	Width      float64       `xml:"width,attr"`
+	Height     float64       `xml:"height,attr"`
+	Rotation   float64       `xml:"rotation,attr"`
+	GID        uint32        `xml:"gid,attr"`
+	Visible    *int          `xml:"visible,attr"`
+	Properties xmlProperties `xml:"properties"`
+	Point      *struct{}     `xml:"point"`
+	Ellipse    *struct{}     `xml:"ellipse"`
+	Polygon    *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polygon"`
+	Polyline *struct {
+		Points string `xml:"points,attr"`
+	} `xml:"polyline"`
+}
+
+// parseTMX decodes a TMX document; dir is the directory of the map file.
+func parseTMX(data []byte, dir string) (*Map, error) {
+	var xm xmlMap
+	if err := xml.Unmarshal(data, &xm); err != nil {
+		return nil, err
+	}
+	m := &Map{
+		Orientation: xm.Orientation,
+		Infinite:    xm.Infinite != 0,
+		Width:       xm.Width,
+		Height:      xm.Height,
+		TileWidth:   xm.TileWidth,
+		TileHeight:  xm.TileHeight,
+		Properties:  xm.Properties.toProperties(),
+	}
+	m.BackgroundColor, _ = parseColor(xm.BackgroundColor)
+
+	for _, xt := range xm.Tilesets {
+		var ts *Tileset
+		var err error
+		if xt.Source != "" {
+			ts, err = loadExternalTileset(xt.Source, dir, xt.FirstGID)
+		} else {
+			ts, err = xt.toTileset(dir)
+			if ts != nil {
+				ts.FirstGID = xt.FirstGID
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.Tilesets = append(m.Tilesets, ts)
+	}
+
+	for _, xl := range xm.Layers {
+		if err := m.addXMLLayer(xl, dir, 0, 0, 1, true); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// parseTSX decodes an external XML tileset.
+func parseTSX(data []byte, dir string) (*Tileset, error) {
+	var xt xmlTileset
+	if err := xml.Unmarshal(data, &xt); err != nil {
+		return nil, err
+	}
+	return xt.toTileset(dir)
+}
+
+func (xt *xmlTileset) toTileset(dir string) (*Tileset, error) {
+	ts := &Tileset{
+		Name:       xt.Name,
+		TileWidth:  xt.TileWidth,
+		TileHeight: xt.TileHeight,
+		Spacing:    xt.Spacing,
+		Margin:     xt.Margin,
+		TileCount:  xt.TileCount,
+		Columns:    xt.Columns,
+		Properties: xt.Properties.toProperties(),
+		Tiles:      make(map[int]*TileInfo),
+	}
+	if xt.Image != nil {
+		ts.Image = resolveRelative(xt.Image.Source, dir)
+		ts.ImageWidth, ts.ImageHeight = xt.Image.Width, xt.Image.Height
+	}
+	for _, t := range xt.Tiles {
+		info := &TileInfo{
+			ID:         t.ID,
+			Type:       firstNonEmpty(t.Class, t.Type),
+			Properties: t.Properties.toProperties(),
+		}
+		for _, f := range t.Frames {
+			info.Animation = append(info.Animation, Frame{
+				TileID:   f.TileID,
+				Duration: float64(f.Duration) / 1000,
+			})
+		}
+		ts.Tiles[t.ID] = info
+	}
+	return ts, nil
+}
+
+// addXMLLayer appends xl (and, for groups, its children) to m.Layers.
+// ox, oy, opacity and visible are inherited from enclosing groups.
+func (m *Map) addXMLLayer(xl xmlLayer, dir string, ox, oy, opacity float64, visible bool) error {
+	if xl.Visible != nil && *xl.Visible == 0 {
+		visible = false
+	}
+	if xl.Opacity != nil {
+		opacity *= *xl.Opacity
+	}
+	ox += xl.OffsetX
+	oy += xl.OffsetY
+
+	l := &Layer{
+		Name:       xl.Name,
+		Visible:    visible,
+		Opacity:    opacity,
+		OffsetX:    ox,
+		OffsetY:    oy,
+		Properties: xl.Properties.toProperties(),
+	}
+
+	switch xl.XMLName.Local {
+	case "group":
+		for _, child := range xl.Children {
+			if err := m.addXMLLayer(child, dir, ox, oy, opacity, visible); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "layer":
+		l.Kind = TileLayer
+		if xl.Data == nil {
+			break
+		}
+		d := xl.Data
+		if len(d.Chunks) > 0 {
+			for _, xc := range d.Chunks {
+				gids, err := decodeData(xc.Text, d.Encoding, d.Compression)
+				if err != nil {
+					return fmt.Errorf("layer %q: %w", xl.Name, err)
+				}
+				l.chunks = append(l.chunks, chunk{xc.X, xc.Y, xc.Width, xc.Height, gids})
+			}
+			break
+		}
+		var gids []uint32
+		if d.Encoding == "" {
+			for _, t := range d.Tiles {
+				gids = append(gids, t.GID)
+			}
+		} else {
+			var err error
+			if gids, err = decodeData(d.Text, d.Encoding, d.Compression); err != nil {
+				return fmt.Errorf("layer %q: %w", xl.Name, err)
+			}
+		}
+		l.chunks = []chunk{{0, 0, xl.Width, xl.Height, gids}}
+
+	case "objectgroup":
+		l.Kind = ObjectLayer
+		for _, xo := range xl.Objects {
+			l.Objects = append(l.Objects, m.xmlObject(xo, l))
+		}
+
+	case "imagelayer":
+		l.Kind = ImageLayer
+		if xl.Image != nil {
+			l.Image = resolveRelative(xl.Image.Source, dir)
+		}
+
+	default:
+		// properties, editorsettings, … are not layers
+		return nil
+	}
+
+	m.Layers = append(m.Layers, l)
+	return nil
+}
+
+func (m *Map) xmlObject(xo xmlObject, l *Layer) *Object {
+	o := &Object{
+		ID:         xo.ID,
+		Name:       xo.Name,
+		Type:       firstNonEmpty(xo.Class, xo.Type),
+		X:          xo.X,
+		Y:          xo.Y,
+		Width:      xo.Width,
+		Height:     xo.Height,
+		Rotation:   xo.Rotation,
+		GID:        xo.GID,
+		Visible:    xo.Visible == nil || *xo.Visible != 0,
+		Point:      xo.Point != nil,
+		Ellipse:    xo.Ellipse != nil,
+		Properties: xo.Properties.toProperties(),
+		Layer:      l,
+	}
+	if xo.Polygon != nil {
+		o.Polygon = parsePoints(xo.Polygon.Points)
+	}
+	if xo.Polyline != nil {
+		o.Polyline = parsePoints(xo.Polyline.Points)
+	}
+	m.inheritTileType(o)
+	return o
+}
+
+func (xp xmlProperties) toProperties() Properties {
+	if len(xp.Property) == 0 {
+		return Properties{}
+	}
+	props := make(Properties, len(xp.Property))
+	for _, p := range xp.Property {
+		v := p.Value
+		if v == "" {
+			v = p.Text
+		}
+		props[p.Name] = v
+	}
+	return props
+}
+
+// parsePoints parses "x1,y1 x2,y2 …".
+func parsePoints(s string) []Point {
+	var pts []Point
+	for _, pair := range strings.Fields(s) {
+		xy := strings.SplitN(pair, ",", 2)
+		if len(xy) != 2 {
+			continue
+		}
+		x, _ := strconv.ParseFloat(xy[0], 64)
+		y, _ := strconv.ParseFloat(xy[1], 64)
+		pts = append(pts, Point{x, y})
+	}
+	return pts
+}
+
+// inheritTileType gives tile objects without a type the class of their tile.
+func (m *Map) inheritTileType(o *Object) {
+	if o.Type != "" || o.GID == 0 {
+		return
+	}
+	if info := m.TileInfo(o.GID); info != nil {
+		o.Type = info.Type
+	}
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,229 @@
+// runt/view.go
+package runt
+
+import (
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
+)
+
+// -----------------------------------------------------------------------------
+// Views (split-screen, picture-in-picture)
+// -----------------------------------------------------------------------------
+
+// View draws a World through a Camera into a rectangle of the (virtual)
+// screen, clipped to it.  Once a World has views, World.Render draws each
+// of them in order instead of the single full-screen pass.
+//
+//	rects := runt.SplitRects(2)
+//	world.AddView(runt.NewView(p1Cam, rects[0]))
+//	world.AddView(runt.NewView(p2Cam, rects[1]))
+//	hud := world.AddView(runt.NewView(runt.NewCamera(), runt.FullRect()))
+//	hud.Layers = []int{HUDLayer} // and Skip: []int{HUDLayer} on the others
+type View struct {
+	Camera *Camera
+	Rect   rl.Rectangle // screen area in virtual pixels
+
+	// Layers limits the view to these layers (nil = all); Skip leaves
+	// these out.  Use them so HUD layers are drawn by one view only.
+	Layers []int
+	Skip   []int
+
+	// Background fills the rectangle first unless fully transparent.
+	Background Color
+
+	Visible bool
+}
+
+// NewView makes a visible view of cam drawn into r.  The camera is sized to
+// the rectangle so following and bounds work in the view's space.
+func NewView(cam *Camera, r rl.Rectangle) *View {
+	cam.SetSize(r.Width, r.Height)
+	return &View{Camera: cam, Rect: r, Visible: true}
+}
+
+// FullRect covers the whole virtual screen.
+func FullRect() rl.Rectangle {
+	return rl.NewRectangle(0, 0, float32(Width), float32(Height))
+}
+
+// SplitRects divides the screen for n players: 2 side by side, 3–4 in a
+// 2×2 grid (the fourth cell empty for 3), more in a row-major grid.
+func SplitRects(n int) []rl.Rectangle {
+	if n <= 1 {
+		return []rl.Rectangle{FullRect()}
+	}
+	cols, rows := 2, 1
+	if n > 2 {
+		for cols*rows < n {
+			if cols <= rows {
+				cols++
+			} else {
+				rows++
+			}
+		}
+	}
+	w, h := float32(Width/cols), float32(Height/rows)
+	rects := make([]rl.Rectangle, n)
+	for i := range rects {
+		rects[i] = rl.NewRectangle(float32(i%cols)*w, float32(i/cols)*h, w, h)
+	}
+	return rects
+}
+
+// Shows reports whether layer is drawn in this view.
+func (v *View) Shows(layer int) bool {
+	if v.Layers != nil && !slices.Contains(v.Layers, layer) {
+		return false
+	}
+	return !slices.Contains(v.Skip, layer)
+}
+
+// ScreenToWorld converts virtual screen coordinates inside the view to
+// world coordinates.
+func (v *View) ScreenToWorld(x, y float32) (float32, float32) {
+	p := rl.GetScreenToWorld2D(rl.Vector2{X: x, Y: y}, v.Camera.camera2D(v.Rect))
+	vx, vy := v.Camera.View()
+	return p.X + vx, p.Y + vy
+}
+
+// WorldToScreen converts world coordinates to virtual screen coordinates.
+func (v *View) WorldToScreen(x, y float32) (float32, float32) {
+	vx, vy := v.Camera.View()
+	p := rl.GetWorldToScreen2D(rl.Vector2{X: x - vx, Y: y - vy}, v.Camera.camera2D(v.Rect))
+	return p.X, p.Y
+}
+
+// Contains reports whether the virtual screen point lies in the view.
+func (v *View) Contains(x, y float32) bool {
+	return rl.CheckCollisionPointRec(rl.Vector2{X: x, Y: y}, v.Rect)
+}
+
+// -----------------------------------------------------------------------------
+// World integration
+// -----------------------------------------------------------------------------
+
+// AddView appends v to the views drawn by Render and returns it.
+func (w *World) AddView(v *View) *View {
+	w.views = append(w.views, v)
+	return v
+}
+
+// RemoveView drops v.  Returns true if it was present.
+func (w *World) RemoveView(v *View) bool {
+	return RemoveElement(&w.views, v)
+}
+
+// ClearViews goes back to drawing through w.Camera alone.
+func (w *World) ClearViews() { w.views = w.views[:0] }
+
+// Views returns the views in draw order.
+func (w *World) Views() []*View { return w.views }
+
+// ActiveCamera is the camera the world is being drawn through right now:
+// the current view's while Render walks the views, w.Camera otherwise.
+func (w *World) ActiveCamera() *Camera {
+	if w.active != nil {
+		return w.active.Camera
+	}
+	return w.Camera
+}
+
+// ActiveView is the view being drawn, or nil outside a views pass.
+func (w *World) ActiveView() *View { return w.active }
+
+// drawing is the World whose Render is running, nil otherwise.
+var drawing *World
+
+// frameCamera is the transform Engine.Run draws the frame with (the
+// CurrentWorld camera), nil outside Game.Draw.  Worlds drawn through a
+// different camera switch away from it and back.
+var frameCamera *rl.Camera2D
+
+// DrawingWorld is the World being rendered, or CurrentWorld outside
+// World.Render.  Entities draw through its camera, not CurrentWorld's, so
+// a UI world keeps its own view.
+func DrawingWorld() *World {
+	if drawing != nil {
+		return drawing
+	}
+	return CurrentWorld
+}
+
+// DrawingCamera is the camera entities are drawn through right now: the
+// active camera of DrawingWorld, or nil without a world.
+func DrawingCamera() *Camera {
+	w := DrawingWorld()
+	if w == nil {
+		return nil
+	}
+	return w.ActiveCamera()
+}
+
+// useCamera switches the frame's transform and view rectangle to
+// w.Camera when it differs from the one the frame was begun with (a UI
+// world drawn after the game world) and returns the func switching back.
+func (w *World) useCamera() func() {
+	if frameCamera == nil {
+		return func() {}
+	}
+	cam := w.Camera.Camera2D()
+	if cam == *frameCamera {
+		return func() {}
+	}
+	graphics.FlushBatch()
+	rl.EndMode2D()
+	rl.BeginMode2D(cam)
+	view := graphics.ViewRect()
+	graphics.SetViewRect(w.Camera.cullRect(0))
+	return func() {
+		graphics.FlushBatch()
+		rl.EndMode2D()
+		rl.BeginMode2D(*frameCamera)
+		graphics.SetViewRect(view)
+	}
+}
+
+// cameras returns every camera the world uses, without duplicates.
+func (w *World) cameras() []*Camera {
+	cams := []*Camera{w.Camera}
+	for _, v := range w.views {
+		if v.Camera != nil && !slices.Contains(cams, v.Camera) {
+			cams = append(cams, v.Camera)
+		}
+	}
+	return cams
+}
+
+// renderViews draws the world once per visible view, each with its own
+// camera transform, view rectangle and scissor rectangle, then restores
+// the transform of the frame.
+func (w *World) renderViews() {
+	graphics.FlushBatch() // sprites so far belong to the full-screen camera
+	rl.EndMode2D()
+	full := graphics.ViewRect()
+	for _, v := range w.views {
+		if !v.Visible || v.Camera == nil {
+			continue
+		}
+		w.active = v
+		rl.BeginScissorMode(int32(v.Rect.X), int32(v.Rect.Y), int32(v.Rect.Width), int32(v.Rect.Height))
+		if v.Background.A > 0 {
+			rl.DrawRectangleRec(v.Rect, v.Background)
+		}
+		rl.BeginMode2D(v.Camera.camera2D(v.Rect))
+		graphics.SetViewRect(v.Camera.cullRect(0))
+		w.renderLayers(v.Camera, v)
+		graphics.FlushBatch()
+		rl.EndMode2D()
+		rl.EndScissorMode()
+	}
+	w.active = nil
+	graphics.SetViewRect(full)
+	if frameCamera != nil {
+		rl.BeginMode2D(*frameCamera)
+	} else {
+		rl.BeginMode2D(w.Camera.Camera2D())
+	}
+}
@@ -0,0 +1,176 @@
+// runt/window.go
+package runt
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/loader"
+)
+
+// -----------------------------------------------------------------------------
+// Window options
+// -----------------------------------------------------------------------------
+
+// Option configures an Engine in NewEngine.
+type Option func(*Engine)
+
+// Resizer is implemented by Games that want to know when the window size
+// changes (resizing, fullscreen toggles, DPI changes).  w and h are window
+// pixels; Width/Height stay the virtual size, or follow the window when
+// there is no virtual resolution.
+type Resizer interface {
+	Resized(w, h int)
+}
+
+// WithResizable lets the user resize the window.
+func WithResizable() Option {
+	return func(e *Engine) { e.resizable = true }
+}
+
+// WithFullscreen starts in borderless fullscreen.
+func WithFullscreen() Option {
+	return func(e *Engine) { e.startFullscreen = true }
+}
+
+// WithVSync turns vertical sync on or off (off by default).
+func WithVSync(on bool) Option {
+	return func(e *Engine) { e.vsync = on }
+}
+
+// WithHighDPI renders at the display's native pixel density.
+func WithHighDPI() Option {
+	return func(e *Engine) { e.highDPI = true }
+}
+
+// WithMinSize stops the window shrinking below w×h.
+func WithMinSize(w, h int) Option {
+	return func(e *Engine) { e.minW, e.minH = w, h }
+}
+
+// WithIcon sets the window icon from an image found through the loader.
+func WithIcon(path string) Option {
+	return func(e *Engine) { e.iconPath = path }
+}
+
+// WithVirtualResolution renders at w×h and scales to the window; see
+// Engine.SetVirtualResolution.
+func WithVirtualResolution(w, h int, mode ScaleMode) Option {
+	return func(e *Engine) { e.SetVirtualResolution(w, h, mode) }
+}
+
+// windowFlags collects the raylib config flags for InitWindow.
+func (e *Engine) windowFlags() uint32 {
+	var flags uint32
+	if e.resizable || e.scaleMode != ScaleNone {
+		flags |= rl.FlagWindowResizable
+	}
+	if e.vsync {
+		flags |= rl.FlagVsyncHint
+	}
+	if e.highDPI {
+		flags |= rl.FlagWindowHighdpi
+	}
+	return flags
+}
+
+// initWindow applies the options that need an open window.
+func (e *Engine) initWindow() {
+	if e.minW > 0 || e.minH > 0 {
+		rl.SetWindowMinSize(e.minW, e.minH)
+	}
+	if e.iconPath != "" {
+		e.SetIcon(e.iconPath)
+	}
+	if e.startFullscreen {
+		e.SetFullscreen(true)
+	}
+	e.lastW, e.lastH = rl.GetScreenWidth(), rl.GetScreenHeight()
+}
+
+// checkResize tells the Game about window size changes.  Without a
+// virtual resolution the game's Width/Height follow the window.
+func (e *Engine) checkResize() {
+	w, h := rl.GetScreenWidth(), rl.GetScreenHeight()
+	if w == e.lastW && h == e.lastH {
+		return
+	}
+	e.lastW, e.lastH = w, h
+	if e.width == 0 || e.height == 0 {
+		Resize(w, h)
+	}
+	if r, ok := e.game.(Resizer); ok {
+		r.Resized(w, h)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Runtime control
+// -----------------------------------------------------------------------------
+
+// SetFullscreen switches borderless fullscreen on or off.
+func (e *Engine) SetFullscreen(on bool) {
+	if on != e.IsFullscreen() {
+		e.ToggleFullscreen()
+	}
+}
+
+// ToggleFullscreen flips between windowed and borderless fullscreen.
+func (e *Engine) ToggleFullscreen() {
+	if rl.IsWindowReady() {
+		rl.ToggleBorderlessWindowed()
+	} else {
+		e.startFullscreen = !e.startFullscreen
+	}
+}
+
+// IsFullscreen reports whether the window is in borderless fullscreen.
+func (e *Engine) IsFullscreen() bool {
+	if rl.IsWindowReady() {
+		return rl.IsWindowState(rl.FlagBorderlessWindowedMode)
+	}
+	return e.startFullscreen
+}
+
+// SetVSync turns vertical sync on or off at runtime.
+func (e *Engine) SetVSync(on bool) {
+	e.vsync = on
+	if !rl.IsWindowReady() {
+		return
+	}
+	if on {
+		rl.SetWindowState(rl.FlagVsyncHint)
+	} else {
+		rl.ClearWindowState(rl.FlagVsyncHint)
+	}
+}
+
+// SetResizable allows or forbids resizing the window at runtime.
+func (e *Engine) SetResizable(on bool) {
+	e.resizable = on
+	if !rl.IsWindowReady() {
+		return
+	}
+	if on {
+		rl.SetWindowState(rl.FlagWindowResizable)
+	} else {
+		rl.ClearWindowState(rl.FlagWindowResizable)
+	}
+}
+
+// SetIcon loads `path` through the loader and uses it as the window icon.
+func (e *Engine) SetIcon(path string) {
+	e.iconPath = path
+	if !rl.IsWindowReady() {
+		return
+	}
+	img := loader.LoadImage(path)
+	rl.SetWindowIcon(*img)
+	rl.UnloadImage(img)
+}
+
+// WindowSize returns the window size in pixels.
+func (e *Engine) WindowSize() (int, int) {
+	if rl.IsWindowReady() {
+		return rl.GetScreenWidth(), rl.GetScreenHeight()
+	}
+	return e.winW, e.winH
+}
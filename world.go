@@ -3,6 +3,9 @@ package runt
 import (
 	"reflect"
 	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/henrypekny/runt/graphics"
 )
 
 // Entity must implement Update, Render and Layer.
@@ -23,9 +26,29 @@ type World struct {
 	addQueue    []Entity
 	removeQueue []Entity
 
-	// optional camera
-	CameraX, CameraY float32
-	UseCamera        bool
+	// Camera is the world's view (see camera.go); views, when present,
+	// draw through their own cameras instead (see view.go)
+	Camera *Camera
+	views  []*View
+	active *View
+
+	// Culling skips entities outside the camera (plus CullMargin pixels)
+	// in Render; see cull.go
+	Culling    bool
+	CullMargin float32
+	cullStats  CullStats
+	broad      Broadphase
+	broadDirty bool // rebuild the index
+	moved      map[Entity]struct{}
+	indexed    map[Entity]rl.Rectangle
+	seen       map[Entity]uint32
+	pass       uint32
+
+	// time (see timescale.go); IgnoreRate runs the world on RealElapsed
+	// so the global Rate (slow motion) doesn't affect it
+	IgnoreRate bool
+	timeScale  float64
+	hitstop    int
 
 	// simple recycling pool: type name -> []Entity
 	pool map[string][]Entity
@@ -63,6 +86,12 @@ func NewWorld() *World {
 		removeQueue: make([]Entity, 0, 16),
 		pool:        make(map[string][]Entity),
 		typeCounts:  make(map[string]int),
+		timeScale:   1,
+		Camera:      NewCamera(),
+		CullMargin:  16,
+		moved:       make(map[Entity]struct{}),
+		indexed:     make(map[Entity]rl.Rectangle),
+		seen:        make(map[Entity]uint32),
 	}
 }
 
@@ -79,6 +108,8 @@ func (w *World) Remove(e Entity) {
 // FlushQueues integrates all queued add/removes.
 // Call this once per frame (e.g. at end of Update or start of Render).
 func (w *World) FlushQueues() {
+	indexing := w.broad != nil && !w.broadDirty
+
 	// --- Removals ---
 	for _, e := range w.removeQueue {
 		layer := e.Layer()
@@ -91,6 +122,12 @@ func (w *World) FlushQueues() {
 
 				// remove this entity from its layer slice
 				w.layers[layer] = append(list[:i], list[i+1:]...)
+				if m, ok := e.(member); ok {
+					m.detach(w)
+				}
+				if indexing {
+					w.unindex(e)
+				}
 				break
 			}
 		}
@@ -109,6 +146,12 @@ func (w *World) FlushQueues() {
 		}
 		// append the new entity
 		w.layers[layer] = append(w.layers[layer], e)
+		if m, ok := e.(member); ok {
+			m.attach(w, e)
+		}
+		if indexing {
+			w.index(e)
+		}
 
 		// increment the type-count
 		typeName := reflect.TypeOf(e).Elem().Name()
@@ -151,24 +194,58 @@ func (w *World) ForEach(fn func(Entity)) {
 	}
 }
 
-// Update all active Entities
+// Update all active Entities.  dt is scaled by the world's TimeScale and
+// then by each entity's own; worlds and entities in hitstop are skipped.
+// In fixed mode the world is snapshotted first, for interpolation.
 func (w *World) Update(dt float64) {
 	w.FlushQueues()
+	if Fixed {
+		w.Snapshot() // before anything moves, hitstop included
+	}
+	dt, ok := w.step(dt)
+	if !ok {
+		for _, c := range w.cameras() {
+			c.Update(0) // shake keeps running through hitstop
+		}
+		return
+	}
+	defer func() {
+		for _, c := range w.cameras() {
+			c.Update(dt)
+		}
+	}()
 	for _, layer := range w.layerOrder {
 		for _, e := range w.layers[layer] {
-			e.Update(dt)
+			edt := dt
+			if ts, ok := e.(TimeScaled); ok {
+				if edt, ok = ts.Tick(dt); !ok {
+					continue
+				}
+			}
+			e.Update(edt)
 		}
 	}
 }
 
-// Render all Entities in front→back order
+// Render all Entities in front→back order through the world's own camera,
+// once per View if the world has any, skipping off-screen ones when
+// Culling is on.
 func (w *World) Render() {
 	w.FlushQueues()
-	for _, layer := range w.layerOrder {
-		for _, e := range w.layers[layer] {
-			e.Render()
-		}
+	w.cullStats = CullStats{}
+	prev := drawing
+	drawing = w
+	defer func() { drawing = prev }()
+	if len(w.views) > 0 {
+		w.renderViews()
+	} else {
+		restore := w.useCamera()
+		w.renderLayers(w.Camera, nil)
+		restore()
 	}
+	// sprites the game draws after the world must land on top of it
+	graphics.FlushBatch()
+	graphics.SetBatchLayer(0)
 }
 
 // BringToFront, SendToBack, BringForward, SendBackward omitted for brevity...